@@ -16,25 +16,61 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// cspURL is the CSP used unless Auth.CSPURL is set
 const cspURL = "https://console.cloud.vmware.com"
 const cspResource = "/csp/gateway/am/api/auth/api-tokens/authorize"
 
-// Auth struct for API and secret key
+// cspOAuthResource is where OAuth apps exchange their client credentials
+const cspOAuthResource = "/csp/gateway/am/api/auth/authorize"
+
+// tokenExpiryDelta is how long before its actual expiry an access token is
+// considered stale, so that a request is never sent with a token that expires in flight.
+const tokenExpiryDelta = time.Minute
+
+// defaultTokenLifetime is used when CSP does not report expires_in for a token.
+const defaultTokenLifetime = 5 * time.Minute
+
+// Auth struct for API and secret key. It uses the client_credentials grant of
+// a CSP OAuth app when ClientID is set and the refresh_token grant of a user
+// API token otherwise.
 type Auth struct {
 	RefreshToken string
+
+	// ClientID and ClientSecret identify a CSP OAuth app, OrgID optionally
+	// selects the organization the app should get a token for.
+	ClientID     string
+	ClientSecret string
+	OrgID        string
+
+	// CSPURL is the base URL of the CSP the refresh token was issued by.
+	CSPURL string
+
+	// Cache optionally persists access tokens across Auth instances.
+	Cache TokenCache
+
+	httpClient *http.Client
+	retry      RetryPolicy
+	logger     Logger
+	mu         sync.Mutex
+	token      *AccessToken
 }
 
 type cspToken struct {
 	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
 }
 
 // SignRequest method to sign all requests
@@ -48,40 +84,138 @@ func (a *Auth) SignRequest(req *http.Request) error {
 		req.ContentLength = int64(len(body))
 	}
 
-	cspToken, err := a.getCspAuthToken()
+	token, err := a.accessToken(req.Context())
 	if err != nil {
 		return err
 	}
-	req.Header.Add("csp-auth-token", cspToken.AccessToken)
+	req.Header.Set("csp-auth-token", token.Token)
 	return nil
 }
 
-func (a *Auth) getCspAuthToken() (*cspToken, error) {
+// Invalidate drops the cached access token, so the next signed request
+// exchanges the refresh token again.
+func (a *Auth) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = nil
+	if a.Cache != nil {
+		a.Cache.Delete(a.cacheKey())
+	}
+}
+
+// accessToken returns a valid access token, from memory, from the token
+// cache or from a new CSP exchange, in that order.
+func (a *Auth) accessToken(ctx context.Context) (*AccessToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token.valid() {
+		return a.token, nil
+	}
+
+	if a.Cache != nil {
+		if token, err := a.Cache.Load(a.cacheKey()); err == nil && token.valid() {
+			a.token = token
+			return token, nil
+		}
+	}
+
+	cspToken, err := a.getCspAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime := time.Duration(cspToken.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+	a.token = &AccessToken{
+		Token:  cspToken.AccessToken,
+		Expiry: time.Now().Add(lifetime),
+	}
+
+	if a.Cache != nil {
+		// A cache that cannot be written only costs a token exchange next time.
+		a.Cache.Store(a.cacheKey(), a.token)
+	}
+	return a.token, nil
+}
+
+// cacheKey identifies the credentials a cached token was issued for, without
+// storing the credentials themselves.
+func (a *Auth) cacheKey() string {
+	credentials := a.RefreshToken
+	if a.clientCredentials() {
+		credentials = strings.Join([]string{a.ClientID, a.ClientSecret, a.OrgID}, "\n")
+	}
+	sum := sha256.Sum256([]byte(a.cspURL() + "\n" + credentials))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *Auth) cspURL() string {
+	if a.CSPURL == "" {
+		return cspURL
+	}
+	return strings.TrimRight(a.CSPURL, "/")
+}
+
+// clientCredentials reports whether the OAuth app grant is used
+func (a *Auth) clientCredentials() bool {
+	return a.ClientID != ""
+}
+
+func (a *Auth) getCspAuthToken(ctx context.Context) (*cspToken, error) {
 	cspToken := new(cspToken)
 
+	resource := cspResource
 	data := url.Values{}
-	data.Set("refresh_token", a.RefreshToken)
-
-	url, _ := url.ParseRequestURI(cspURL)
-	url.Path = cspResource
+	if a.clientCredentials() {
+		resource = cspOAuthResource
+		data.Set("grant_type", "client_credentials")
+		if a.OrgID != "" {
+			data.Set("orgId", a.OrgID)
+		}
+	} else {
+		data.Set("refresh_token", a.RefreshToken)
+	}
 
-	req, err := http.NewRequest("POST", url.String(), strings.NewReader(data.Encode()))
+	url, err := url.ParseRequestURI(a.cspURL())
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Content-Length", strconv.Itoa(len(data.Encode())))
-	resp, err := http.DefaultClient.Do(req)
+	url.Path = strings.TrimRight(url.Path, "/") + resource
+
+	httpClient := a.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	// Exchanging the same refresh token again is harmless, so the exchange is always retried.
+	resp, attempts, err := a.retry.send(ctx, true, a.logger, func() (*http.Response, error) {
+		req, err := http.NewRequest("POST", url.String(), strings.NewReader(data.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Content-Length", strconv.Itoa(len(data.Encode())))
+		if a.clientCredentials() {
+			req.SetBasicAuth(a.ClientID, a.ClientSecret)
+		}
+		return httpClient.Do(req.WithContext(ctx))
+	})
+	if attempts > 1 && a.logger != nil {
+		a.logger("[ RETRY ] CSP token exchange took %d attempts\n", attempts)
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		message := new(bytes.Buffer)
-		message.ReadFrom(resp.Body)
-		msg := fmt.Sprintf("%s", message.String())
-		return nil, NewError(msg)
+		message, _ := ioutil.ReadAll(resp.Body)
+		apiErr := newAPIError("POST", url.Path, resp, message)
+		apiErr.TokenExchange = true
+		return nil, apiErr
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(cspToken)
@@ -17,8 +17,12 @@ package client
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 
@@ -45,3 +49,110 @@ func TestSignRequest(t *testing.T) {
 
 	assert.Contains(t, authToken, "fake-access-token", "Request Authorization header doesn't contain csp-auth-token.")
 }
+
+func TestSignRequestReusesAccessToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	auth := &Auth{RefreshToken: "asdf"}
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("GET", "", nil)
+		assert.Nil(t, auth.SignRequest(req))
+		assert.Equal(t, "fake-access-token", req.Header.Get("csp-auth-token"))
+	}
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "access token should be exchanged only once.")
+}
+
+func TestSignRequestRefreshesExpiringToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	auth := &Auth{RefreshToken: "asdf"}
+	auth.token = &AccessToken{Token: "old-access-token", Expiry: time.Now().Add(tokenExpiryDelta / 2)}
+
+	req, _ := http.NewRequest("GET", "", nil)
+	auth.SignRequest(req)
+	assert.Equal(t, "fake-access-token", req.Header.Get("csp-auth-token"))
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestSignRequestUsesTokenCache(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tokens")
+	defer os.RemoveAll(dir)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	first := &Auth{RefreshToken: "asdf", Cache: NewFileTokenCache(dir, "default")}
+	first.SignRequest(httptest.NewRequest("GET", "/", nil))
+
+	second := &Auth{RefreshToken: "asdf", Cache: NewFileTokenCache(dir, "default")}
+	req := httptest.NewRequest("GET", "/", nil)
+	second.SignRequest(req)
+	assert.Equal(t, "fake-access-token", req.Header.Get("csp-auth-token"))
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "cached token should be reused by a new Auth.")
+
+	other := &Auth{RefreshToken: "other", Cache: NewFileTokenCache(dir, "default")}
+	other.SignRequest(httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "cached token must not be used for other credentials.")
+}
+
+func TestInvalidateDeletesCachedToken(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tokens")
+	defer os.RemoveAll(dir)
+
+	cache := NewFileTokenCache(dir, "default")
+	auth := &Auth{RefreshToken: "asdf", Cache: cache}
+	cache.Store(auth.cacheKey(), &AccessToken{Token: "cached", Expiry: time.Now().Add(time.Hour)})
+
+	auth.Invalidate()
+	_, err := cache.Load(auth.cacheKey())
+	assert.NotNil(t, err, "cached token should be deleted.")
+}
+
+func TestSignRequestWithCustomCSP(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://csp.staging.example.com/local"+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	auth := &Auth{RefreshToken: "asdf", CSPURL: "https://csp.staging.example.com/local/"}
+	req := httptest.NewRequest("GET", "/", nil)
+	err := auth.SignRequest(req)
+	assert.Nil(t, err, "SignRequest shouldn't return error.")
+	assert.Equal(t, "fake-access-token", req.Header.Get("csp-auth-token"))
+}
+
+func TestSignRequestWithInvalidCSP(t *testing.T) {
+	auth := &Auth{RefreshToken: "asdf", CSPURL: "not a url"}
+	err := auth.SignRequest(httptest.NewRequest("GET", "/", nil))
+	assert.NotNil(t, err, "SignRequest should return error for an invalid CSP URL.")
+}
+
+func TestSignRequestWithClientCredentials(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspOAuthResource, func(req *http.Request) (*http.Response, error) {
+		id, secret, ok := req.BasicAuth()
+		req.ParseForm()
+		if !ok || id != "app-id" || secret != "app-secret" || req.PostForm.Get("grant_type") != "client_credentials" || req.PostForm.Get("orgId") != "org-1" {
+			return httpmock.NewStringResponse(http.StatusBadRequest, `{"message":"invalid_client"}`), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, refreshTokenJSONPayload), nil
+	})
+
+	auth := &Auth{ClientID: "app-id", ClientSecret: "app-secret", OrgID: "org-1"}
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.Nil(t, auth.SignRequest(req))
+	assert.Equal(t, "fake-access-token", req.Header.Get("csp-auth-token"))
+}
+
+func TestClientCredentialsCacheKey(t *testing.T) {
+	app := &Auth{ClientID: "app-id", ClientSecret: "app-secret"}
+	other := &Auth{ClientID: "app-id", ClientSecret: "other-secret"}
+	user := &Auth{RefreshToken: "app-id"}
+	assert.NotEqual(t, app.cacheKey(), other.cacheKey())
+	assert.NotEqual(t, app.cacheKey(), user.cacheKey())
+}
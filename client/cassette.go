@@ -0,0 +1,255 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// cassetteExt is the extension of interaction files in a cassette directory
+const cassetteExt = ".json"
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// missingInteractionError is returned when replaying a request that was not recorded
+type missingInteractionError struct {
+	dir, method, url string
+}
+
+func (e *missingInteractionError) Error() string {
+	return fmt.Sprintf("no interaction recorded in %s for %s %s", e.dir, e.method, e.url)
+}
+
+// Interaction is a recorded request and the response it got, with
+// credentials redacted
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request half of an Interaction
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response half of an Interaction
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is a directory of interactions, one file each, named in the order
+// they were recorded. Use Record as a Tracer to add to it and use the
+// cassette as a transport to play it back without a network.
+type Cassette struct {
+	dir string
+
+	mu           sync.Mutex
+	next         int
+	err          error
+	interactions []*Interaction
+	played       []bool
+}
+
+// NewRecorder returns a cassette adding interactions to dir, after the ones
+// already there
+func NewRecorder(dir string) (*Cassette, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	names, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Cassette{dir: dir, next: len(names) + 1}, nil
+}
+
+// LoadCassette reads the interactions recorded in dir for playback
+func LoadCassette(dir string) (*Cassette, error) {
+	names, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+
+	c := &Cassette{dir: dir}
+	for _, name := range names {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		i := &Interaction{}
+		if err := json.Unmarshal(data, i); err != nil {
+			return nil, fmt.Errorf("reading %s: %s", name, err)
+		}
+		c.interactions = append(c.interactions, i)
+	}
+	c.played = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// Record writes an exchange to the cassette, failed exchanges are skipped.
+// Write errors are kept for Err so that recording never fails a command.
+func (c *Cassette) Record(e *Exchange) {
+	if e.Err != nil {
+		return
+	}
+
+	i := &Interaction{
+		Request: RecordedRequest{
+			Method: e.Method,
+			URL:    e.URL,
+			Header: e.RequestHeader,
+			Body:   string(e.RequestBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: e.StatusCode,
+			Status:     e.Status,
+			Header:     e.ResponseHeader,
+			Body:       string(e.ResponseBody),
+		},
+	}
+	data, err := json.MarshalIndent(i, "", "  ")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		name := fmt.Sprintf("%04d-%s-%s%s", c.next, e.Method, pathSlug(e.URL), cassetteExt)
+		err = ioutil.WriteFile(filepath.Join(c.dir, name), data, 0600)
+		c.next++
+	}
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// Err returns the first error met while recording
+func (c *Cassette) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// RoundTrip answers req with the first unplayed interaction for the same
+// method and URL, preferring one with the same body. Once all of them have
+// been played the last one keeps being answered, so that polling and
+// repeated commands work.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = redactBody(req.Header.Get("Content-Type"), b)
+	}
+	url := redactURL(req.URL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match, last := -1, -1
+	for n, i := range c.interactions {
+		if i.Request.Method != req.Method || i.Request.URL != url {
+			continue
+		}
+		last = n
+		if c.played[n] {
+			continue
+		}
+		if match == -1 || (i.Request.Body == string(body) && c.interactions[match].Request.Body != string(body)) {
+			match = n
+		}
+	}
+	if match == -1 {
+		match = last
+	}
+	if match == -1 {
+		return nil, &missingInteractionError{dir: c.dir, method: req.Method, url: url}
+	}
+	c.played[match] = true
+
+	recorded := c.interactions[match].Response
+	header := http.Header{}
+	for name, values := range recorded.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	return &http.Response{
+		StatusCode:    recorded.StatusCode,
+		Status:        recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func cassetteFiles(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), cassetteExt) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// pathSlug turns the path of rawURL into something usable in a file name
+func pathSlug(rawURL string) string {
+	path := rawURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+	}
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[i:]
+	}
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	slug := strings.Trim(unsafeFileChars.ReplaceAllString(path, "-"), "-")
+	if len(slug) > 60 {
+		slug = slug[len(slug)-60:]
+	}
+	if slug == "" {
+		slug = "root"
+	}
+	return slug
+}
@@ -0,0 +1,95 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordCloudAccounts(t *testing.T, dir string) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", httpmock.NewStringResponder(http.StatusOK, CloudAccountsJSONPayload))
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	recorder, err := NewRecorder(dir)
+	assert.Nil(t, err)
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint, WithTracer(recorder.Record))
+	_, err = client.GetCloudAccounts(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, recorder.Err())
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cassette")
+	defer os.RemoveAll(dir)
+	recordCloudAccounts(t, dir)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Equal(t, 2, len(files))
+	for _, f := range files {
+		data, _ := ioutil.ReadFile(dir + "/" + f.Name())
+		assert.False(t, strings.Contains(string(data), "ApiKey"), "the refresh token should be redacted.")
+		assert.False(t, strings.Contains(string(data), "fake-access-token"), "the access token should be redacted.")
+	}
+	assert.True(t, strings.HasPrefix(files[1].Name(), "0002-GET-"))
+
+	player, err := LoadCassette(dir)
+	assert.Nil(t, err)
+	client, _ := MakeClient("replay", defaultAPIEndpoint, WithTransport(player))
+	for i := 0; i < 2; i++ {
+		accounts, err := client.GetCloudAccounts(context.Background())
+		assert.Nil(t, err)
+		assert.NotEmpty(t, accounts)
+	}
+}
+
+func TestCassetteRecordAppends(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cassette")
+	defer os.RemoveAll(dir)
+	recordCloudAccounts(t, dir)
+	recordCloudAccounts(t, dir)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Equal(t, 4, len(files))
+}
+
+func TestCassetteReplayMissingInteraction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cassette")
+	defer os.RemoveAll(dir)
+	recordCloudAccounts(t, dir)
+
+	player, _ := LoadCassette(dir)
+	client, _ := MakeClient("replay", defaultAPIEndpoint, WithTransport(player))
+	_, err := client.GetCloudAccountByID(context.Background(), "unknown")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no interaction recorded")
+}
+
+func TestLoadCassetteEmpty(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cassette")
+	defer os.RemoveAll(dir)
+
+	_, err := LoadCassette(dir)
+	assert.NotNil(t, err)
+}
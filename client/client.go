@@ -28,7 +28,16 @@ import (
 )
 
 type clientOptions struct {
-	interceptor Interceptor
+	interceptor  Interceptor
+	tokenCache   TokenCache
+	cspURL       string
+	retry        *RetryPolicy
+	logger       Logger
+	tracers      []Tracer
+	transport    http.RoundTripper
+	clientID     string
+	clientSecret string
+	orgID        string
 }
 
 // Option type
@@ -46,24 +55,87 @@ func WithInterceptor(ci Interceptor) Option {
 	}
 }
 
+// WithTransport returns a ClientOption for sending requests, including the
+// CSP token exchange, through rt instead of the default transport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(opts *clientOptions) {
+		opts.transport = rt
+	}
+}
+
+// WithClientCredentials returns a ClientOption for authenticating as a CSP
+// OAuth app instead of with a refresh token, orgID may be empty.
+func WithClientCredentials(clientID, clientSecret, orgID string) Option {
+	return func(opts *clientOptions) {
+		opts.clientID = clientID
+		opts.clientSecret = clientSecret
+		opts.orgID = orgID
+	}
+}
+
+// WithTokenCache returns a ClientOption for persisting CSP access
+// tokens across clients.
+func WithTokenCache(cache TokenCache) Option {
+	return func(opts *clientOptions) {
+		opts.tokenCache = cache
+	}
+}
+
+// WithCSPURL returns a ClientOption for exchanging refresh tokens with
+// a CSP other than the production one.
+func WithCSPURL(cspURL string) Option {
+	return func(opts *clientOptions) {
+		opts.cspURL = cspURL
+	}
+}
+
+// WithRetryPolicy returns a ClientOption for replacing DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(opts *clientOptions) {
+		opts.retry = &policy
+	}
+}
+
+// WithLogger returns a ClientOption for reporting retries.
+func WithLogger(logger Logger) Option {
+	return func(opts *clientOptions) {
+		opts.logger = logger
+	}
+}
+
 // Client struct
 type Client struct {
 	client   http.Client
 	endpoint string
 	opts     clientOptions
-	auth     Auth
+	auth     *Auth
 }
 
 // MakeClient make client
-func MakeClient(refreshToken, endpoint string) (*Client, error) {
+func MakeClient(refreshToken, endpoint string, opts ...Option) (*Client, error) {
+
+	a := &Auth{RefreshToken: refreshToken}
+	i := Interceptor(a.SignRequest)
+	c := newClient(endpoint, append(opts, WithInterceptor(i))...)
 
-	if refreshToken == "None" || refreshToken == "" {
+	if c.opts.clientID != "" {
+		if c.opts.clientSecret == "" {
+			return nil, NewError(content.ErrorMissingClientSecret)
+		}
+		a.RefreshToken = ""
+		a.ClientID = c.opts.clientID
+		a.ClientSecret = c.opts.clientSecret
+		a.OrgID = c.opts.orgID
+	} else if refreshToken == "None" || refreshToken == "" {
 		return nil, NewError(content.ErrorMissingAPIOrSecretKey)
 	}
 
-	a := Auth{RefreshToken: refreshToken}
-	i := Interceptor(a.SignRequest)
-	c := newClient(endpoint, WithInterceptor(i))
+	a.Cache = c.opts.tokenCache
+	a.CSPURL = c.opts.cspURL
+	a.httpClient = &c.client
+	a.retry = c.retryPolicy()
+	a.logger = c.opts.logger
+	c.auth = a
 
 	return c, nil
 }
@@ -79,14 +151,41 @@ func newClient(endpoint string, opts ...Option) *Client {
 		opt(&client.opts)
 	}
 
+	if len(client.opts.tracers) > 0 {
+		client.client.Transport = &traceTransport{base: client.opts.transport, tracers: client.opts.tracers}
+	} else if client.opts.transport != nil {
+		client.client.Transport = client.opts.transport
+	}
+
 	return client
 }
 
 // Do performs an HTTP request with a given context - the response will be decoded
 // into obj.
 func (c *Client) Do(ctx context.Context, method, path string, body io.Reader, obj interface{}) error {
+	return c.DoWithHeader(ctx, method, path, nil, body, obj)
+}
 
-	resp, err := c.makeRequest(ctx, method, path, body)
+// DoWithHeader is Do with extra request headers. The response is decoded into
+// obj, unless obj is a *[]byte which then receives the undecoded body.
+func (c *Client) DoWithHeader(ctx context.Context, method, path string, header http.Header, body io.Reader, obj interface{}) error {
+	var payload []byte
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		payload = b
+	}
+
+	resp, err := c.send(ctx, method, path, header, payload)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && c.auth != nil {
+		// The cached access token may have been revoked, exchange the refresh token once more.
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		c.auth.Invalidate()
+		resp, err = c.send(ctx, method, path, header, payload)
+	}
 	if err != nil {
 		return err
 	}
@@ -94,16 +193,16 @@ func (c *Client) Do(ctx context.Context, method, path string, body io.Reader, ob
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		message := new(bytes.Buffer)
-		message.ReadFrom(resp.Body)
-		msg := fmt.Sprintf("%s", message.String())
-		return NewError(msg)
+		message, _ := ioutil.ReadAll(resp.Body)
+		return newAPIError(method, path, resp, message)
 	}
 
 	// Read all of resp.Body regardless of status code so we don't leak connections.
 	// The extra io.Copy is to ensure everything has been read, since a json.Decoder doesn't
 	// have that guarantee.
-	if obj != nil {
+	if raw, ok := obj.(*[]byte); ok {
+		*raw, err = ioutil.ReadAll(resp.Body)
+	} else if obj != nil {
 		err = json.NewDecoder(resp.Body).Decode(obj)
 	}
 
@@ -112,23 +211,50 @@ func (c *Client) Do(ctx context.Context, method, path string, body io.Reader, ob
 	return err
 }
 
-func (c *Client) makeRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	req, err := c.buildRequest(method, path, body)
+// send makes the request, retrying it according to the client's retry policy
+func (c *Client) send(ctx context.Context, method, path string, header http.Header, payload []byte) (*http.Response, error) {
+	resp, attempts, err := c.retryPolicy().send(ctx, isIdempotent(method), c.opts.logger, func() (*http.Response, error) {
+		return c.makeRequest(ctx, method, path, header, payload)
+	})
+	if attempts > 1 && c.opts.logger != nil {
+		c.opts.logger("[ RETRY ] %s %s took %d attempts\n", method, path, attempts)
+	}
+	return resp, err
+}
+
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.opts.retry != nil {
+		return *c.opts.retry
+	}
+	return DefaultRetryPolicy
+}
+
+func (c *Client) makeRequest(ctx context.Context, method, path string, header http.Header, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := c.buildRequest(ctx, method, path, header, body)
 	if err != nil {
 		return nil, err
 	}
 	return ctxhttp.Do(ctx, &c.client, req)
 }
 
-func (c *Client) buildRequest(method, path string, body io.Reader) (*http.Request, error) {
+func (c *Client) buildRequest(ctx context.Context, method, path string, header http.Header, body io.Reader) (*http.Request, error) {
 	urlPath := fmt.Sprintf("%s/%s", c.endpoint, path)
 	req, err := http.NewRequest(method, urlPath, body)
 	if err != nil {
 		return nil, err
 	}
+	// The interceptor may make requests of its own, such as the token exchange.
+	req = req.WithContext(ctx)
 	if (method == "POST" || method == "PUT") && body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
 
 	if c.opts.interceptor != nil {
 		if err := c.opts.interceptor(req); err != nil {
@@ -16,7 +16,9 @@ package client
 
 // Basic imports
 import (
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -66,6 +68,14 @@ func (suite *MakeClientTestSuite) TestMakeClient() {
 	assert.NotNil(suite.T(), client.endpoint, "client.endpoint is nil")
 }
 
+// TestMakeClientWithCSPURL MakeClient with a custom CSP
+func (suite *MakeClientTestSuite) TestMakeClientWithCSPURL() {
+	client, err := MakeClient("APIkey", "endpoint", WithCSPURL("https://csp.example.com"))
+	assert.Nil(suite.T(), err, "MakeClient should not return error for a custom CSP URL")
+	assert.Equal(suite.T(), "https://csp.example.com", client.auth.CSPURL)
+	assert.True(suite.T(), client.auth.httpClient == &client.client, "token exchange should use the client's http.Client")
+}
+
 func (suite *MakeClientTestSuite) testMakeClientError(err error) {
 	assert.NotNil(suite.T(), err, "MakeClient should return error for invalid ApiKey or endpoint.")
 	assert.Contains(suite.T(), err.Error(), content.ErrorMissingAPIOrSecretKey)
@@ -117,7 +127,54 @@ func TestBuildRequest(t *testing.T) {
 
 	i := Interceptor(func(req *http.Request) error { return fmt.Errorf("Return error") })
 	c := newClient("http://test.com", WithInterceptor(i))
-	_, err := c.buildRequest("GET", "http://test.com", nil)
+	_, err := c.buildRequest(context.Background(), "GET", "http://test.com", nil, nil)
 
 	assert.NotNil(t, err, "buildRequest should return error.")
 }
+
+func TestDoRefreshesTokenOnUnauthorized(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	calls := 0
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/cloudaccounts", func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.NewStringResponse(http.StatusUnauthorized, ""), nil
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		return httpmock.NewStringResponse(http.StatusCreated, string(body)), nil
+	})
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint)
+	account := &CloudAccount{}
+	err := client.Do(context.Background(), "POST", "cloudaccounts", strings.NewReader(`{"_id":"cloudAccountID"}`), account)
+	assert.Nil(t, err, "Do shouldn't return error after refreshing the token.")
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "cloudAccountID", account.ID, "request body should be sent again.")
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["POST "+cspURL+cspResource])
+}
+
+func TestMakeClientWithClientCredentials(t *testing.T) {
+	_, err := MakeClient("", defaultAPIEndpoint, WithClientCredentials("app-id", "app-secret", ""))
+	assert.Nil(t, err, "a client ID and secret should be enough to make a client.")
+
+	_, err = MakeClient("", defaultAPIEndpoint, WithClientCredentials("app-id", "", ""))
+	assert.NotNil(t, err, "a client ID without secret should be rejected.")
+}
+
+func TestDoWithHeaderRawBody(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/v1/report", func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(http.StatusOK, "accept="+req.Header.Get("Accept")), nil
+	})
+
+	c, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	var raw []byte
+	err := c.DoWithHeader(context.Background(), "GET", "v1/report", http.Header{"Accept": {"text/csv"}}, nil, &raw)
+	assert.Nil(t, err)
+	assert.Equal(t, "accept=text/csv", string(raw))
+}
@@ -20,6 +20,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
 
 	"github.com/imdario/mergo"
@@ -54,6 +57,32 @@ type CreateCloudAccountInput struct {
 	DirectoryID    string
 	SubscriptionID string
 	Tags           string
+
+	// Scan replaces the scan settings when set, by default accounts are
+	// scanned in all regions and updates keep the settings they had
+	Scan *ScanSettings
+}
+
+//ScanSettings tell whether, how often and where a cloud account is scanned,
+//empty Interval and Region keep the default or current value
+type ScanSettings struct {
+	Enabled  bool
+	Interval string
+	Region   string
+}
+
+// apply sets the scan settings of info
+func (s *ScanSettings) apply(info *CloudInfo) {
+	if s == nil {
+		return
+	}
+	info.ScanEnabled = s.Enabled
+	if s.Interval != "" {
+		info.ScanInterval = s.Interval
+	}
+	if s.Region != "" {
+		info.ScanRegion = s.Region
+	}
 }
 
 //CloudInfo listed all info of cloud accounts
@@ -104,26 +133,132 @@ type UpdateCloudAccountInput struct {
 	CloudID string
 }
 
+//CloudAccountFilter narrows a cloud accounts query. Lists match any of their
+//values except Tags which must all be present, Name is a glob as matched by
+//path.Match and nil IsValid or IsDraft match both.
+type CloudAccountFilter struct {
+	Providers    []string
+	Environments []string
+	Tags         []string
+	Name         string
+	IsValid      *bool
+	IsDraft      *bool
+}
+
+// cloudAccountsPage is a page of cloud accounts, ContinuationToken is empty
+// on the last one
+type cloudAccountsPage struct {
+	Results           []*CloudAccount `json:"results"`
+	ContinuationToken string          `json:"continuationToken"`
+}
+
 // GetCloudAccounts method for cloud command
 func (c *Client) GetCloudAccounts(ctx context.Context) ([]*CloudAccount, error) {
-	// clouds := []*CloudAccount{}
+	return c.QueryCloudAccounts(ctx, CloudAccountFilter{})
+}
+
+//QueryCloudAccounts returns the cloud accounts matching filter, an empty list
+//when there are none. The filter is sent as query parameters, and applied to
+//the response too for servers ignoring some of them. Servers answering with
+//pages instead of a list are paged through.
+func (c *Client) QueryCloudAccounts(ctx context.Context, filter CloudAccountFilter) ([]*CloudAccount, error) {
+	query := filter.query()
 	clouds := make([]*CloudAccount, 0)
+	// A server handing out a token twice would otherwise be paged forever
+	seen := map[string]bool{}
+	for {
+		resource := "cloudaccounts"
+		if len(query) > 0 {
+			resource += "?" + query.Encode()
+		}
+		var body []byte
+		if err := c.Do(ctx, "GET", resource, nil, &body); err != nil {
+			return nil, err
+		}
 
-	err := c.Do(ctx, "GET", "cloudaccounts", nil, &clouds)
-	if err != nil {
-		return nil, NewError(err.Error())
+		var list []*CloudAccount
+		err := json.Unmarshal(body, &list)
+		if err == nil {
+			clouds = append(clouds, list...)
+			break
+		}
+		page := cloudAccountsPage{}
+		if json.Unmarshal(body, &page) != nil || page.Results == nil {
+			return nil, err
+		}
+		clouds = append(clouds, page.Results...)
+		if page.ContinuationToken == "" || len(page.Results) == 0 || seen[page.ContinuationToken] {
+			break
+		}
+		seen[page.ContinuationToken] = true
+		query.Set("continuationToken", page.ContinuationToken)
 	}
+
+	matching := make([]*CloudAccount, 0, len(clouds))
 	for _, account := range clouds {
 		if account.Provider == "Azure" {
 			account.AccountID = account.SubscriptionID
 		}
+		if filter.Match(account) {
+			matching = append(matching, account)
+		}
 	}
+	return matching, nil
+}
 
-	if len(clouds) == 0 {
-		return nil, NewError(content.ErrorNoCloudAccountsFound)
+// query returns the query parameters of the filter
+func (f *CloudAccountFilter) query() url.Values {
+	query := url.Values{}
+	for _, provider := range f.Providers {
+		query.Add("provider", provider)
+	}
+	for _, environment := range f.Environments {
+		query.Add("environment", environment)
+	}
+	for _, tag := range f.Tags {
+		query.Add("tag", tag)
 	}
+	if f.IsValid != nil {
+		query.Set("isValid", strconv.FormatBool(*f.IsValid))
+	}
+	if f.IsDraft != nil {
+		query.Set("isDraft", strconv.FormatBool(*f.IsDraft))
+	}
+	return query
+}
 
-	return clouds, nil
+//Match tells whether account matches the filter, comparing names, providers
+//and environments regardless of case
+func (f *CloudAccountFilter) Match(account *CloudAccount) bool {
+	if len(f.Providers) > 0 && !containsFold(f.Providers, account.Provider) {
+		return false
+	}
+	if len(f.Environments) > 0 && !containsFold(f.Environments, account.Environment) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !containsFold(account.Tags, tag) {
+			return false
+		}
+	}
+	if f.Name != "" {
+		if ok, _ := path.Match(strings.ToLower(f.Name), strings.ToLower(account.Name)); !ok {
+			return false
+		}
+	}
+	if f.IsValid != nil && *f.IsValid != account.IsValid {
+		return false
+	}
+	return f.IsDraft == nil || *f.IsDraft == account.IsDraft
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetCloudAccountByID method getting cloud account by user ID
@@ -221,6 +356,7 @@ func (c *Client) CreateCloudAccount(ctx context.Context, input *CreateCloudAccou
 	} else {
 		return nil, NewError("Unsupported CloudAccount type")
 	}
+	input.Scan.apply(&cloudCreateInput)
 	cloudAccount, err := c.sendCloudCreateRequest(ctx, &cloudCreateInput)
 	if err != nil {
 		return nil, err
@@ -282,6 +418,7 @@ func (t *UpdateCloudAccountInput) mergeAndGetJSON(account *CloudAccount) ([]byte
 	if t.Tags != "" {
 		updateInfo.Tags = strings.Split(t.Tags, "|")
 	}
+	t.Scan.apply(updateInfo)
 	jsonStr, err := json.Marshal(updateInfo)
 	if err != nil {
 		return nil, err
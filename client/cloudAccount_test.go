@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -110,17 +111,72 @@ func TestGetCloudAccountsFailureInvalidCloudAccountresponse(t *testing.T) {
 	assert.Equal(t, "json: cannot unmarshal object into Go value of type []*client.CloudAccount", err.Error())
 }
 
-func TestGetCloudAccountsFailedNoCloudAccountsFound(t *testing.T) {
+func TestGetCloudAccountsEmpty(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", httpmock.NewStringResponder(http.StatusOK, `[]`))
 	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
 
 	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
-	_, err := client.GetCloudAccounts(context.Background())
-	assert.NotNil(t, err, "GetCloudAccounts should return error.")
-	assert.Equal(t, "No cloud accounts found.", err.Error())
+	clouds, err := client.GetCloudAccounts(context.Background())
+	assert.Nil(t, err, "An empty team is not an error.")
+	assert.Equal(t, []*CloudAccount{}, clouds)
+}
+
+func TestQueryCloudAccountsFilter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	// The server ignores isDraft, which is then applied to the response
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts?environment=Production&isDraft=false&provider=AWS&tag=pci",
+		httpmock.NewStringResponder(http.StatusOK, `[
+			{"_id": "1", "name": "prod-web", "provider": "AWS", "environment": "Production", "tags": ["pci", "web"]},
+			{"_id": "2", "name": "prod-draft", "provider": "AWS", "environment": "Production", "tags": ["PCI"], "isDraft": true},
+			{"_id": "3", "name": "dev-web", "provider": "AWS", "environment": "Production", "tags": ["pci"]}
+		]`))
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	draft := false
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	clouds, err := client.QueryCloudAccounts(context.Background(), CloudAccountFilter{
+		Providers: []string{"AWS"}, Environments: []string{"Production"}, Tags: []string{"pci"}, Name: "PROD-*", IsDraft: &draft,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(clouds))
+	assert.Equal(t, "1", clouds[0].ID)
+}
+
+func TestQueryCloudAccountsPages(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": [{"_id": "1", "provider": "Azure", "subscriptionId": "sub"}], "continuationToken": "next"}`))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts?continuationToken=next",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": [{"_id": "2"}], "continuationToken": ""}`))
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	clouds, err := client.GetCloudAccounts(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(clouds))
+	assert.Equal(t, "sub", clouds[0].AccountID)
+	assert.Equal(t, "2", clouds[1].ID)
+}
+
+func TestQueryCloudAccountsRepeatedToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": [{"_id": "1"}], "continuationToken": "a"}`))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts?continuationToken=a",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": [{"_id": "2"}], "continuationToken": "b"}`))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts?continuationToken=b",
+		httpmock.NewStringResponder(http.StatusOK, `{"results": [{"_id": "3"}], "continuationToken": "a"}`))
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
 
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	clouds, err := client.GetCloudAccounts(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(clouds), "paging stops at the repeated token")
 }
 
 func TestGetCloudAccountByIDSuccess(t *testing.T) {
@@ -280,6 +336,34 @@ func TestUpdateCloudAccountSuccess(t *testing.T) {
 	assert.Nil(t, err, "UpdateCloudAccount shouldn't return error.")
 }
 
+func TestUpdateCloudAccountScanSettings(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts/cloudAccountID", httpmock.NewStringResponder(http.StatusOK,
+		`{"_id":"cloudAccountID","name":"prod","provider":"AWS","scanEnabled":true,"scanInterval":"Weekly","scanRegion":"All"}`))
+	var sent CloudInfo
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/cloudaccounts/cloudAccountID/update", func(req *http.Request) (*http.Response, error) {
+		json.NewDecoder(req.Body).Decode(&sent)
+		return httpmock.NewStringResponse(http.StatusOK, createdCloudAccountJSONPayload), nil
+	})
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	_, err := client.UpdateCloudAccount(context.Background(), &UpdateCloudAccountInput{CloudID: "cloudAccountID"})
+	assert.Nil(t, err)
+	assert.True(t, sent.ScanEnabled)
+	assert.Equal(t, "Weekly", sent.ScanInterval)
+
+	_, err = client.UpdateCloudAccount(context.Background(), &UpdateCloudAccountInput{
+		CreateCloudAccountInput: CreateCloudAccountInput{Scan: &ScanSettings{Interval: "Daily", Region: "us-east-1"}},
+		CloudID:                 "cloudAccountID",
+	})
+	assert.Nil(t, err)
+	assert.False(t, sent.ScanEnabled)
+	assert.Equal(t, "Daily", sent.ScanInterval)
+	assert.Equal(t, "us-east-1", sent.ScanRegion)
+}
+
 func TestReValidateRoleSuccess(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
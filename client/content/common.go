@@ -4,8 +4,8 @@ const (
 	//ErrorMissingAPIOrSecretKey error
 	ErrorMissingAPIOrSecretKey = "Missing API key or/and Secret key. Please run 'coreo configure' to configure them."
 
-	//ErrorNoCloudAccountsFound error
-	ErrorNoCloudAccountsFound = "No cloud accounts found."
+	//ErrorMissingClientSecret error
+	ErrorMissingClientSecret = "Missing client secret for the OAuth app client ID. Please run 'vss configure --client-id ID' to configure it."
 
 	//ErrorNoCloudAccountWithIDFound error
 	ErrorNoCloudAccountWithIDFound = "No cloud account with ID %s found."
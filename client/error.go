@@ -1,5 +1,18 @@
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeaders are the response headers a server request or correlation ID is read from
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id", "X-Vmw-Request-Id"}
+
+// messageKeys are the JSON error payload fields a human readable message is read from
+var messageKeys = []string{"message", "error_message", "errorMessage", "error_description", "error"}
+
 type errorString struct {
 	Message string `json:"error_message"`
 }
@@ -13,3 +26,95 @@ func (e *errorString) Error() string {
 func NewError(text string) error {
 	return &errorString{text}
 }
+
+// APIError is returned for a response with a status code of 300 or above, use
+// errors.As to inspect it
+type APIError struct {
+	StatusCode int                    `json:"statusCode"`
+	Method     string                 `json:"method"`
+	Path       string                 `json:"path"`
+	RequestID  string                 `json:"requestId,omitempty"`
+	Message    string                 `json:"message"`
+	Body       string                 `json:"-"`
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+
+	// TokenExchange is set when the request was the exchange of the API token with CSP.
+	TokenExchange bool `json:"tokenExchange,omitempty"`
+}
+
+// Error formats the request, the status and the server message
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a response, body is the response body already read
+func newAPIError(method, path string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+		Body:       string(body),
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return apiErr
+	}
+	apiErr.Payload = payload
+	for _, k := range messageKeys {
+		if msg, ok := payload[k].(string); ok && msg != "" {
+			apiErr.Message = msg
+			break
+		}
+	}
+	if apiErr.RequestID == "" {
+		for _, k := range []string{"requestId", "correlationId"} {
+			if id, ok := payload[k].(string); ok && id != "" {
+				apiErr.RequestID = id
+				break
+			}
+		}
+	}
+	return apiErr
+}
+
+// InterruptedError is returned when an operation made of several steps was
+// cancelled or timed out, it reports which steps were completed
+type InterruptedError struct {
+	Operation string
+	Completed []string
+	Remaining []string
+	Err       error
+}
+
+// Error lists the completed and remaining steps
+func (e *InterruptedError) Error() string {
+	completed, remaining := "none", "none"
+	if len(e.Completed) > 0 {
+		completed = strings.Join(e.Completed, ", ")
+	}
+	if len(e.Remaining) > 0 {
+		remaining = strings.Join(e.Remaining, ", ")
+	}
+	return fmt.Sprintf("%s interrupted (%s). Completed: %s. Not completed: %s", e.Operation, e.Err, completed, remaining)
+}
+
+// Unwrap returns the context error that interrupted the operation
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,80 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoReturnsAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts/missing", func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(http.StatusNotFound, `{"message":"Cloud account not found","code":"NOT_FOUND"}`)
+		resp.Header.Set("X-Request-Id", "request-1")
+		return resp, nil
+	})
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint)
+	_, err := client.GetCloudAccountByID(context.Background(), "missing")
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "GetCloudAccountByID should return an APIError.")
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, "cloudaccounts/missing", apiErr.Path)
+	assert.Equal(t, "request-1", apiErr.RequestID)
+	assert.Equal(t, "Cloud account not found", apiErr.Message)
+	assert.Equal(t, "NOT_FOUND", apiErr.Payload["code"])
+	assert.False(t, apiErr.TokenExchange)
+	assert.Equal(t, "GET cloudaccounts/missing: 404 Not Found: Cloud account not found (request ID request-1)", err.Error())
+}
+
+func TestDoReturnsAPIErrorForPlainBody(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", httpmock.NewStringResponder(http.StatusBadRequest, "bad request\n"))
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint)
+	_, err := client.GetCloudAccounts(context.Background())
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "GetCloudAccounts should return an APIError.")
+	assert.Equal(t, "bad request", apiErr.Message)
+	assert.Nil(t, apiErr.Payload)
+}
+
+func TestTokenExchangeReturnsAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusBadRequest, `{"error":"invalid_grant","error_description":"invalid refresh token","requestId":"csp-1"}`))
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint)
+	err := client.Do(context.Background(), "GET", "cloudaccounts", nil, nil)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr), "token exchange should return an APIError.")
+	assert.True(t, apiErr.TokenExchange)
+	assert.Equal(t, "invalid refresh token", apiErr.Message)
+	assert.Equal(t, "csp-1", apiErr.RequestID)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// defaultFindingsPageSize is how many findings are asked for per request
+const defaultFindingsPageSize = 1000
+
+//Finding is a rule violation found on a cloud object
+type Finding struct {
+	ID                     string `json:"id"`
+	RuleID                 string `json:"ruleId"`
+	RuleName               string `json:"ruleName,omitempty"`
+	Level                  string `json:"level"`
+	Status                 string `json:"status"`
+	Provider               string `json:"cloudProvider"`
+	CloudAccountID         string `json:"cloudAccountId"`
+	Region                 string `json:"region"`
+	Service                string `json:"service,omitempty"`
+	ObjectID               string `json:"objectId"`
+	ObjectPath             string `json:"objectPath,omitempty"`
+	FirstObservedTimestamp string `json:"firstObservedTimestamp,omitempty"`
+	LastObservedTimestamp  string `json:"lastObservedTimestamp,omitempty"`
+}
+
+//FindingFilter narrows a findings query, empty lists match everything. AsOf
+//asks for the findings as they were at that RFC 3339 time instead of now
+type FindingFilter struct {
+	CloudAccountIDs []string `json:"cloudAccountIds,omitempty"`
+	RuleIDs         []string `json:"ruleIds,omitempty"`
+	Levels          []string `json:"levels,omitempty"`
+	Providers       []string `json:"providers,omitempty"`
+	Regions         []string `json:"regions,omitempty"`
+	Statuses        []string `json:"statuses,omitempty"`
+	AsOf            string   `json:"asOf,omitempty"`
+}
+
+//Match tells whether finding matches the filter, comparing levels, providers
+//and statuses regardless of case. AsOf is not checked.
+func (f *FindingFilter) Match(finding *Finding) bool {
+	return matchAny(f.CloudAccountIDs, finding.CloudAccountID) &&
+		matchAny(f.RuleIDs, finding.RuleID) &&
+		matchAny(f.Levels, finding.Level) &&
+		matchAny(f.Providers, finding.Provider) &&
+		matchAny(f.Regions, finding.Region) &&
+		matchAny(f.Statuses, finding.Status)
+}
+
+// matchAny is containsFold, except that an empty list matches everything
+func matchAny(values []string, value string) bool {
+	return len(values) == 0 || containsFold(values, value)
+}
+
+//PaginationInfo selects a page of a query
+type PaginationInfo struct {
+	ContinuationToken string `json:"continuationToken,omitempty"`
+	PageSize          int    `json:"pageSize,omitempty"`
+}
+
+//FindingQuery is the body of a findings query
+type FindingQuery struct {
+	Filters        FindingFilter  `json:"filters"`
+	PaginationInfo PaginationInfo `json:"paginationInfo"`
+}
+
+//FindingsPage is a page of findings, ContinuationToken is empty on the last one
+type FindingsPage struct {
+	TotalCount        int        `json:"totalCount"`
+	Results           []*Finding `json:"results"`
+	ContinuationToken string     `json:"continuationToken"`
+}
+
+// QueryFindings returns one page of the findings matching query
+func (c *Client) QueryFindings(ctx context.Context, query *FindingQuery) (*FindingsPage, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FindingsPage{}
+	if err := c.Do(ctx, "POST", "findings/query", bytes.NewReader(body), page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetFindings pages through the findings matching filter, stopping after
+// limit findings when limit is positive
+func (c *Client) GetFindings(ctx context.Context, filter FindingFilter, limit int) ([]*Finding, error) {
+	query := &FindingQuery{
+		Filters:        filter,
+		PaginationInfo: PaginationInfo{PageSize: defaultFindingsPageSize},
+	}
+
+	findings := make([]*Finding, 0)
+	// A server handing out a token twice would otherwise be paged forever
+	seen := map[string]bool{}
+	for {
+		if limit > 0 && limit-len(findings) < query.PaginationInfo.PageSize {
+			query.PaginationInfo.PageSize = limit - len(findings)
+		}
+
+		page, err := c.QueryFindings(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, page.Results...)
+
+		if page.ContinuationToken == "" || len(page.Results) == 0 || (limit > 0 && len(findings) >= limit) || seen[page.ContinuationToken] {
+			break
+		}
+		seen[page.ContinuationToken] = true
+		query.PaginationInfo.ContinuationToken = page.ContinuationToken
+	}
+
+	if limit > 0 && len(findings) > limit {
+		findings = findings[:limit]
+	}
+	return findings, nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// registerFindingsPages answers findings queries with pages of size findings
+// until total findings were returned, recording the queries in queries
+func registerFindingsPages(total int, queries *[]FindingQuery) {
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/findings/query", func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		query := FindingQuery{}
+		json.Unmarshal(body, &query)
+		*queries = append(*queries, query)
+
+		start := 0
+		fmt.Sscanf(query.PaginationInfo.ContinuationToken, "%d", &start)
+		page := FindingsPage{TotalCount: total}
+		for i := start; i < total && i < start+query.PaginationInfo.PageSize; i++ {
+			page.Results = append(page.Results, &Finding{ID: fmt.Sprintf("f%d", i), RuleID: "rule"})
+		}
+		if next := start + len(page.Results); next < total {
+			page.ContinuationToken = fmt.Sprintf("%d", next)
+		}
+		return httpmock.NewJsonResponse(http.StatusOK, page)
+	})
+}
+
+func TestGetFindingsAllPages(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	var queries []FindingQuery
+	registerFindingsPages(2500, &queries)
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	filter := FindingFilter{Levels: []string{"High"}, CloudAccountIDs: []string{"c1"}}
+	findings, err := client.GetFindings(context.Background(), filter, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2500, len(findings))
+	assert.Equal(t, 3, len(queries))
+	assert.Equal(t, filter, queries[2].Filters)
+	assert.Equal(t, "2000", queries[2].PaginationInfo.ContinuationToken)
+}
+
+func TestGetFindingsLimit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	var queries []FindingQuery
+	registerFindingsPages(2500, &queries)
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	findings, err := client.GetFindings(context.Background(), FindingFilter{}, 1200)
+	assert.Nil(t, err)
+	assert.Equal(t, 1200, len(findings))
+	assert.Equal(t, 2, len(queries))
+	assert.Equal(t, 200, queries[1].PaginationInfo.PageSize)
+}
+
+func TestGetFindingsRepeatedToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	queries := 0
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/findings/query", func(req *http.Request) (*http.Response, error) {
+		queries++
+		return httpmock.NewJsonResponse(http.StatusOK, FindingsPage{
+			Results:           []*Finding{{ID: fmt.Sprintf("f%d", queries)}},
+			ContinuationToken: "same",
+		})
+	})
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	findings, err := client.GetFindings(context.Background(), FindingFilter{}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, queries, "paging stops when the token comes back")
+	assert.Equal(t, 2, len(findings))
+}
+
+func TestGetFindingsFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/findings/query", httpmock.NewStringResponder(http.StatusBadRequest, `{"message":"invalid filter"}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	_, err := client.GetFindings(context.Background(), FindingFilter{}, 0)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid filter")
+}
+
+func TestFindingFilterMatch(t *testing.T) {
+	finding := &Finding{RuleID: "rule", Level: "High", Status: "Open", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1"}
+
+	assert.True(t, (&FindingFilter{}).Match(finding))
+	assert.True(t, (&FindingFilter{Levels: []string{"Medium", "high"}, Providers: []string{"aws"}}).Match(finding))
+	assert.True(t, (&FindingFilter{CloudAccountIDs: []string{"c1"}, Statuses: []string{"Open"}}).Match(finding))
+	assert.False(t, (&FindingFilter{CloudAccountIDs: []string{"c2"}}).Match(finding))
+	assert.False(t, (&FindingFilter{RuleIDs: []string{"other"}}).Match(finding))
+	assert.False(t, (&FindingFilter{Regions: []string{"eu-west-1"}}).Match(finding))
+	assert.False(t, (&FindingFilter{Statuses: []string{"Resolved"}}).Match(finding))
+}
@@ -0,0 +1,201 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HAR collects traced exchanges into an HTTP Archive 1.2 document, which
+// browsers and most HTTP tools can open. Use its Trace method as a Tracer.
+type HAR struct {
+	mu      sync.Mutex
+	creator harCreator
+	entries []harEntry
+}
+
+type harLog struct {
+	Log harContent `json:"log"`
+}
+
+type harContent struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harBody        `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harBody struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewHAR returns an empty HAR naming the program that recorded it
+func NewHAR(name, version string) *HAR {
+	return &HAR{creator: harCreator{Name: name, Version: version}}
+}
+
+// Trace adds an exchange to the archive
+func (h *HAR) Trace(e *Exchange) {
+	ms := float64(e.Duration) / float64(time.Millisecond)
+	entry := harEntry{
+		StartedDateTime: e.Started.Format("2006-01-02T15:04:05.000Z07:00"),
+		Time:            ms,
+		Request: harRequest{
+			Method:      e.Method,
+			URL:         e.URL,
+			HTTPVersion: e.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.RequestHeader),
+			QueryString: harQuery(e.URL),
+			HeadersSize: -1,
+			BodySize:    len(e.RequestBody),
+		},
+		Response: harResponse{
+			Status:      e.StatusCode,
+			StatusText:  http.StatusText(e.StatusCode),
+			HTTPVersion: e.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.ResponseHeader),
+			Content: harBody{
+				Size:     len(e.ResponseBody),
+				MimeType: e.ResponseHeader.Get("Content-Type"),
+				Text:     string(e.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(e.ResponseBody),
+		},
+		Timings: harTimings{Send: 0, Wait: ms, Receive: 0},
+	}
+	if len(e.RequestBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: e.RequestHeader.Get("Content-Type"),
+			Text:     string(e.RequestBody),
+		}
+	}
+	if e.Err != nil {
+		entry.Error = e.Err.Error()
+	}
+
+	h.mu.Lock()
+	h.entries = append(h.entries, entry)
+	h.mu.Unlock()
+}
+
+// WriteFile writes the archive to path, readable by the current user only
+// since responses may contain account details
+func (h *HAR) WriteFile(path string) error {
+	h.mu.Lock()
+	entries := append([]harEntry{}, h.entries...)
+	h.mu.Unlock()
+
+	data, err := json.MarshalIndent(harLog{Log: harContent{Version: "1.2", Creator: h.creator, Entries: entries}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	res := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			res = append(res, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func harQuery(rawURL string) []harNameValue {
+	res := []harNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return res
+	}
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range query[name] {
+			res = append(res, harNameValue{Name: name, Value: value})
+		}
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+//NotificationTarget receives a notification for each new finding matching its
+//severities and cloud accounts, all of them when they are empty
+type NotificationTarget struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	URL             string   `json:"url,omitempty"`
+	Emails          []string `json:"emails,omitempty"`
+	Levels          []string `json:"levels,omitempty"`
+	CloudAccountIDs []string `json:"cloudAccountIds,omitempty"`
+	Enabled         bool     `json:"enabled"`
+	CreatedBy       string   `json:"createdBy,omitempty"`
+	CreatedAt       string   `json:"createdAt,omitempty"`
+}
+
+//NotificationTestResult tells whether a sample notification was delivered
+type NotificationTestResult struct {
+	Delivered  bool   `json:"delivered"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// GetNotificationTargets returns all notification targets
+func (c *Client) GetNotificationTargets(ctx context.Context) ([]*NotificationTarget, error) {
+	targets := make([]*NotificationTarget, 0)
+	if err := c.Do(ctx, "GET", "notifications", nil, &targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// GetNotificationTargetByID returns the notification target with ID targetID
+func (c *Client) GetNotificationTargetByID(ctx context.Context, targetID string) (*NotificationTarget, error) {
+	target := &NotificationTarget{}
+	if err := c.Do(ctx, "GET", fmt.Sprintf("notifications/%s", targetID), nil, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// CreateNotificationTarget creates a notification target and returns it with its ID
+func (c *Client) CreateNotificationTarget(ctx context.Context, target *NotificationTarget) (*NotificationTarget, error) {
+	body, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &NotificationTarget{}
+	if err := c.Do(ctx, "POST", "notifications", bytes.NewReader(body), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateNotificationTarget replaces the notification target with the ID of target
+func (c *Client) UpdateNotificationTarget(ctx context.Context, target *NotificationTarget) (*NotificationTarget, error) {
+	body, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &NotificationTarget{}
+	if err := c.Do(ctx, "PUT", fmt.Sprintf("notifications/%s", target.ID), bytes.NewReader(body), updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteNotificationTarget deletes the notification target with ID targetID
+func (c *Client) DeleteNotificationTarget(ctx context.Context, targetID string) error {
+	return c.Do(ctx, "DELETE", fmt.Sprintf("notifications/%s", targetID), nil, nil)
+}
+
+// TestNotificationTarget sends a sample notification to the target with ID
+// targetID and returns whether it was delivered
+func (c *Client) TestNotificationTarget(ctx context.Context, targetID string) (*NotificationTestResult, error) {
+	result := &NotificationTestResult{}
+	if err := c.Do(ctx, "POST", fmt.Sprintf("notifications/%s/test", targetID), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
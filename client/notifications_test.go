@@ -0,0 +1,93 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNotificationTargetsSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/notifications", httpmock.NewStringResponder(http.StatusOK,
+		`[{"id":"n1","name":"security","type":"Slack","url":"https://hooks.slack.com/services/T/B/X","levels":["High"],"enabled":true}]`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	targets, err := client.GetNotificationTargets(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []*NotificationTarget{{ID: "n1", Name: "security", Type: "Slack", URL: "https://hooks.slack.com/services/T/B/X", Levels: []string{"High"}, Enabled: true}}, targets)
+}
+
+func TestCreateNotificationTargetSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	var body string
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/notifications", func(req *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+		return httpmock.NewStringResponse(http.StatusOK, `{"id":"n1","name":"oncall","type":"Email"}`), nil
+	})
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	created, err := client.CreateNotificationTarget(context.Background(), &NotificationTarget{Name: "oncall", Type: "Email", Emails: []string{"oncall@example.com"}, Enabled: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "n1", created.ID)
+	assert.Equal(t, `{"name":"oncall","type":"Email","emails":["oncall@example.com"],"enabled":true}`, body)
+}
+
+func TestUpdateNotificationTargetSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("PUT", defaultAPIEndpoint+"/notifications/n1", httpmock.NewStringResponder(http.StatusOK, `{"id":"n1","name":"oncall","enabled":false}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	updated, err := client.UpdateNotificationTarget(context.Background(), &NotificationTarget{ID: "n1", Name: "oncall"})
+	assert.Nil(t, err)
+	assert.Equal(t, &NotificationTarget{ID: "n1", Name: "oncall"}, updated)
+}
+
+func TestDeleteNotificationTargetFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("DELETE", defaultAPIEndpoint+"/notifications/n1", httpmock.NewStringResponder(http.StatusNotFound, `{"message":"not found"}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	err := client.DeleteNotificationTarget(context.Background(), "n1")
+	assert.NotNil(t, err)
+}
+
+func TestTestNotificationTargetSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/notifications/n1/test", httpmock.NewStringResponder(http.StatusOK,
+		`{"delivered":false,"statusCode":404,"message":"no_service"}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	result, err := client.TestNotificationTarget(context.Background(), "n1")
+	assert.Nil(t, err)
+	assert.Equal(t, &NotificationTestResult{StatusCode: 404, Message: "no_service"}, result)
+}
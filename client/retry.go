@@ -0,0 +1,200 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how requests failing with 429, 5xx or a network
+// error are retried. Only idempotent requests are retried after a 5xx or a
+// network error, since those may have been processed by the server.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries.
+	MaxRetries int
+
+	// BaseDelay is the delay before the first retry, it doubles for every further retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps a single backoff delay. A server asking for a longer
+	// delay through Retry-After is not retried.
+	MaxDelay time.Duration
+
+	// MaxElapsed is the total time budget for all attempts, 0 means no budget.
+	// A retry that cannot start within the budget is not made.
+	MaxElapsed time.Duration
+
+	// jitter spreads out retries, policies built without one share
+	// fallbackJitter
+	jitter *jitterSource
+}
+
+// jitterSource is a random source seeded per process, so that concurrent
+// runs of the CLI do not pick the same delays, and safe for concurrent use
+type jitterSource struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newJitterSource() *jitterSource {
+	return &jitterSource{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// int63n returns a random number in [0, n)
+func (j *jitterSource) int63n(n int64) int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.rand.Int63n(n)
+}
+
+var fallbackJitter = newJitterSource()
+
+// DefaultRetryPolicy is the retry policy clients use unless configured otherwise
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+	MaxElapsed: 2 * time.Minute,
+	jitter:     newJitterSource(),
+}
+
+// Logger prints diagnostic messages, such as retries, for verbose output.
+type Logger func(format string, v ...interface{})
+
+// send runs request until it succeeds, fails permanently or the policy is exhausted.
+// It returns the last response or error along with the number of attempts made.
+func (p RetryPolicy) send(ctx context.Context, idempotent bool, logf Logger, request func() (*http.Response, error)) (*http.Response, int, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := request()
+		if attempt > p.MaxRetries || !p.retryable(ctx, idempotent, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+					return resp, attempt, err
+				}
+				delay = retryAfter
+			}
+		}
+		if p.MaxElapsed > 0 && time.Since(start)+delay > p.MaxElapsed {
+			return resp, attempt, err
+		}
+
+		if logf != nil {
+			reason := ""
+			if err != nil {
+				reason = err.Error()
+			} else {
+				reason = resp.Status
+			}
+			logf("[ RETRY ] Attempt %d of %d failed (%s), retrying in %s\n", attempt, p.MaxRetries+1, reason, delay)
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p RetryPolicy) retryable(ctx context.Context, idempotent bool, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		// Only transport failures are worth retrying, not requests that could not be built or signed.
+		urlErr, transport := err.(*url.Error)
+		if transport {
+			// A missing interaction will still be missing on the next attempt.
+			_, missing := urlErr.Err.(*missingInteractionError)
+			transport = !missing
+		}
+		return idempotent && transport
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return idempotent && resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns the jittered delay before retry number attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Equal jitter keeps at least half of the delay, while spreading out
+	// clients that failed at the same time.
+	jitter := p.jitter
+	if jitter == nil {
+		jitter = fallbackJitter
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + jitter.int63n(half+1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
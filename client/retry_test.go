@@ -0,0 +1,173 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	BaseDelay:  time.Millisecond,
+	MaxDelay:   10 * time.Millisecond,
+	MaxElapsed: time.Second,
+}
+
+// failingResponder fails the first failures calls with status before succeeding
+func failingResponder(failures, status int, header http.Header, body string) (httpmock.Responder, *int) {
+	calls := 0
+	return func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls <= failures {
+			resp := httpmock.NewStringResponse(status, "")
+			for k, v := range header {
+				resp.Header[k] = v
+			}
+			return resp, nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, body), nil
+	}, &calls
+}
+
+func TestDoRetriesIdempotentRequest(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	responder, calls := failingResponder(2, http.StatusServiceUnavailable, nil, `[]`)
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", responder)
+
+	var logs []string
+	logger := func(format string, v ...interface{}) { logs = append(logs, fmt.Sprintf(format, v...)) }
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy), WithLogger(logger))
+	err := client.Do(context.Background(), "GET", "cloudaccounts", nil, &[]*CloudAccount{})
+	assert.Nil(t, err, "Do shouldn't return error once a retry succeeds.")
+	assert.Equal(t, 3, *calls)
+	assert.Contains(t, logs[len(logs)-1], "GET cloudaccounts took 3 attempts")
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	responder, calls := failingResponder(5, http.StatusBadGateway, nil, `[]`)
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", responder)
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy))
+	err := client.Do(context.Background(), "GET", "cloudaccounts", nil, nil)
+	assert.NotNil(t, err, "Do should return error once retries are exhausted.")
+	assert.Equal(t, 3, *calls)
+}
+
+func TestDoDoesNotRetryNonIdempotentRequestOnServerError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	responder, calls := failingResponder(1, http.StatusInternalServerError, nil, `{}`)
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/cloudaccounts", responder)
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy))
+	err := client.Do(context.Background(), "POST", "cloudaccounts", strings.NewReader(`{}`), nil)
+	assert.NotNil(t, err, "POST should not be retried after a 5xx.")
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoRetriesTooManyRequests(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	responder, calls := failingResponder(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, `{}`)
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/cloudaccounts", responder)
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy))
+	err := client.Do(context.Background(), "POST", "cloudaccounts", strings.NewReader(`{}`), nil)
+	assert.Nil(t, err, "429 should be retried for any method.")
+	assert.Equal(t, 2, *calls)
+}
+
+func TestDoDoesNotWaitForLongRetryAfter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	responder, calls := failingResponder(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}, `{}`)
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", responder)
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy))
+	err := client.Do(context.Background(), "GET", "cloudaccounts", nil, nil)
+	assert.NotNil(t, err, "a Retry-After beyond MaxDelay should not be waited for.")
+	assert.Equal(t, 1, *calls)
+}
+
+func TestTokenExchangeIsRetried(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	responder, calls := failingResponder(1, http.StatusServiceUnavailable, nil, refreshTokenJSONPayload)
+	httpmock.RegisterResponder("POST", cspURL+cspResource, responder)
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/cloudaccounts", httpmock.NewStringResponder(http.StatusOK, `[]`))
+
+	client, _ := MakeClient("APIkey", defaultAPIEndpoint, WithRetryPolicy(testRetryPolicy))
+	err := client.Do(context.Background(), "GET", "cloudaccounts", nil, nil)
+	assert.Nil(t, err, "Do shouldn't return error once the token exchange succeeds.")
+	assert.Equal(t, 2, *calls)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(delay), float64(2*time.Second))
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for i := 0; i < 20; i++ {
+		delay := policy.backoff(1)
+		assert.True(t, delay >= 500*time.Millisecond && delay <= time.Second, "first delay out of range: %s", delay)
+		delay = policy.backoff(10)
+		assert.True(t, delay >= 2500*time.Millisecond && delay <= 5*time.Second, "delay should be capped: %s", delay)
+	}
+}
+
+func TestJitterSourcesDiffer(t *testing.T) {
+	a, b := newJitterSource(), newJitterSource()
+	time.Sleep(time.Millisecond)
+	c := newJitterSource()
+	draw := func(j *jitterSource) []int64 {
+		values := make([]int64, 5)
+		for i := range values {
+			values[i] = j.int63n(1 << 40)
+		}
+		return values
+	}
+	first := draw(a)
+	assert.True(t, !assert.ObjectsAreEqual(first, draw(b)) || !assert.ObjectsAreEqual(first, draw(c)),
+		"jitter sources should not all repeat the same sequence")
+	assert.NotNil(t, DefaultRetryPolicy.jitter)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+//ComplianceMapping lists the controls of a compliance framework a rule checks
+type ComplianceMapping struct {
+	Framework string   `json:"framework"`
+	Controls  []string `json:"controls,omitempty"`
+}
+
+//Rule is a check Secure State runs against cloud objects
+type Rule struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Provider    string               `json:"provider"`
+	Service     string               `json:"service"`
+	Level       string               `json:"level"`
+	Compliance  []*ComplianceMapping `json:"complianceFrameworks,omitempty"`
+	Remediation string               `json:"remediation,omitempty"`
+}
+
+// GetRules returns the rule catalog
+func (c *Client) GetRules(ctx context.Context) ([]*Rule, error) {
+	rules := make([]*Rule, 0)
+	if err := c.Do(ctx, "GET", "rules", nil, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetRuleByID returns a rule of the catalog
+func (c *Client) GetRuleByID(ctx context.Context, ruleID string) (*Rule, error) {
+	rule := &Rule{}
+	if err := c.Do(ctx, "GET", fmt.Sprintf("rules/%s", ruleID), nil, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
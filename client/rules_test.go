@@ -0,0 +1,52 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const rulesJSONPayload = `[{"id":"rule-1","name":"Bucket is public","provider":"AWS","service":"s3","level":"High",
+"complianceFrameworks":[{"framework":"CIS","controls":["2.1"]}],"remediation":"Block public access"}]`
+
+func TestGetRulesSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/rules", httpmock.NewStringResponder(http.StatusOK, rulesJSONPayload))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	rules, err := client.GetRules(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rules))
+	assert.Equal(t, &ComplianceMapping{Framework: "CIS", Controls: []string{"2.1"}}, rules[0].Compliance[0])
+	assert.Equal(t, "Block public access", rules[0].Remediation)
+}
+
+func TestGetRuleByIDFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/rules/rule-2", httpmock.NewStringResponder(http.StatusNotFound, `{"message":"rule not found"}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	_, err := client.GetRuleByID(context.Background(), "rule-2")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,64 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+//Suppression accepts the risk of the findings of a rule, an object, a cloud
+//account or a combination of them until it expires
+type Suppression struct {
+	ID             string `json:"id,omitempty"`
+	RuleID         string `json:"ruleId,omitempty"`
+	ObjectID       string `json:"objectId,omitempty"`
+	CloudAccountID string `json:"cloudAccountId,omitempty"`
+	Justification  string `json:"justification"`
+	Owner          string `json:"owner"`
+	ExpiresAt      string `json:"expiresAt"`
+	CreatedBy      string `json:"createdBy,omitempty"`
+	CreatedAt      string `json:"createdAt,omitempty"`
+}
+
+// GetSuppressions returns all suppressions
+func (c *Client) GetSuppressions(ctx context.Context) ([]*Suppression, error) {
+	suppressions := make([]*Suppression, 0)
+	if err := c.Do(ctx, "GET", "suppressions", nil, &suppressions); err != nil {
+		return nil, err
+	}
+	return suppressions, nil
+}
+
+// CreateSuppression creates a suppression and returns it with its ID
+func (c *Client) CreateSuppression(ctx context.Context, suppression *Suppression) (*Suppression, error) {
+	body, err := json.Marshal(suppression)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Suppression{}
+	if err := c.Do(ctx, "POST", "suppressions", bytes.NewReader(body), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// DeleteSuppression deletes the suppression with ID suppressionID
+func (c *Client) DeleteSuppression(ctx context.Context, suppressionID string) error {
+	return c.Do(ctx, "DELETE", fmt.Sprintf("suppressions/%s", suppressionID), nil, nil)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSuppressionsSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("GET", defaultAPIEndpoint+"/suppressions", httpmock.NewStringResponder(http.StatusOK,
+		`[{"id":"s1","ruleId":"rule","justification":"accepted","owner":"me","expiresAt":"2026-12-31T00:00:00Z"}]`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	suppressions, err := client.GetSuppressions(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []*Suppression{{ID: "s1", RuleID: "rule", Justification: "accepted", Owner: "me", ExpiresAt: "2026-12-31T00:00:00Z"}}, suppressions)
+}
+
+func TestCreateSuppressionSuccess(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	var body string
+	httpmock.RegisterResponder("POST", defaultAPIEndpoint+"/suppressions", func(req *http.Request) (*http.Response, error) {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+		return httpmock.NewStringResponse(http.StatusOK, `{"id":"s1","cloudAccountId":"c1"}`), nil
+	})
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	created, err := client.CreateSuppression(context.Background(), &Suppression{CloudAccountID: "c1", Justification: "accepted", Owner: "me", ExpiresAt: "2026-12-31T00:00:00Z"})
+	assert.Nil(t, err)
+	assert.Equal(t, "s1", created.ID)
+	assert.Equal(t, `{"cloudAccountId":"c1","justification":"accepted","owner":"me","expiresAt":"2026-12-31T00:00:00Z"}`, body)
+}
+
+func TestDeleteSuppressionFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", cspURL+cspResource, httpmock.NewStringResponder(http.StatusOK, refreshTokenJSONPayload))
+	httpmock.RegisterResponder("DELETE", defaultAPIEndpoint+"/suppressions/s1", httpmock.NewStringResponder(http.StatusNotFound, `{"message":"not found"}`))
+
+	client, _ := MakeClient("ApiKey", defaultAPIEndpoint)
+	err := client.DeleteSuppression(context.Background(), "s1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AccessToken is a CSP access token together with the time it expires
+type AccessToken struct {
+	Token  string    `json:"access_token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+func (t *AccessToken) valid() bool {
+	return t != nil && t.Token != "" && time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenCache persists access tokens so they can be reused across client instances.
+// key identifies the credentials the token was issued for.
+type TokenCache interface {
+	Load(key string) (*AccessToken, error)
+	Store(key string, token *AccessToken) error
+	Delete(key string) error
+}
+
+type fileTokenCache struct {
+	path string
+}
+
+type fileTokenCacheEntry struct {
+	Key string `json:"key"`
+	AccessToken
+}
+
+// NewFileTokenCache returns a TokenCache that keeps the token of one profile
+// in a file under dir, readable only by the current user.
+func NewFileTokenCache(dir, profile string) TokenCache {
+	return &fileTokenCache{
+		path: filepath.Join(dir, profile+".json"),
+	}
+}
+
+// Load reads the cached token, it fails if the token was issued for other credentials
+func (c *fileTokenCache) Load(key string) (*AccessToken, error) {
+	b, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &fileTokenCacheEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+	if entry.Key != key {
+		return nil, NewError("cached token was issued for other credentials")
+	}
+	return &entry.AccessToken, nil
+}
+
+// Store writes the token to the cache file
+func (c *fileTokenCache) Store(key string, token *AccessToken) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(&fileTokenCacheEntry{Key: key, AccessToken: *token})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, b, 0600)
+}
+
+// Delete removes the cache file
+func (c *fileTokenCache) Delete(key string) error {
+	err := os.Remove(c.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
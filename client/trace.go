@@ -0,0 +1,243 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces credentials in traced requests and responses
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never traced in clear text, compared case-insensitively
+var sensitiveHeaders = map[string]bool{
+	"authorization":             true,
+	"csp-auth-token":            true,
+	"cookie":                    true,
+	"set-cookie":                true,
+	"proxy-authorization":       true,
+	"ocp-apim-subscription-key": true,
+}
+
+// sensitiveFields are query, form and JSON fields never traced in clear text,
+// compared case-insensitively. "key" is the Azure application key of a cloud
+// account and webhook URIs embed their own access code.
+var sensitiveFields = map[string]bool{
+	"access_token":      true,
+	"refresh_token":     true,
+	"id_token":          true,
+	"client_secret":     true,
+	"password":          true,
+	"secret":            true,
+	"key":               true,
+	"code":              true,
+	"sig":               true,
+	"webhookserviceuri": true,
+}
+
+// Exchange is a traced HTTP request and its response with credentials redacted
+type Exchange struct {
+	Started        time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	Proto          string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	Status         string
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	Err            error
+}
+
+// Tracer is called with every request sent by a Client, including the CSP
+// token exchange, once its response has been read.
+type Tracer func(*Exchange)
+
+// WithTracer returns a ClientOption for tracing requests, it may be given
+// more than once.
+func WithTracer(t Tracer) Option {
+	return func(opts *clientOptions) {
+		opts.tracers = append(opts.tracers, t)
+	}
+}
+
+// traceTransport records requests going through base, or through the default
+// transport when base is nil. The default transport is looked up for each
+// request so that it can be replaced.
+type traceTransport struct {
+	base    http.RoundTripper
+	tracers []Tracer
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := &Exchange{
+		Started:       time.Now(),
+		Method:        req.Method,
+		URL:           redactURL(req.URL),
+		Proto:         req.Proto,
+		RequestHeader: redactHeader(req.Header),
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		e.RequestBody = redactBody(req.Header.Get("Content-Type"), body)
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		var body []byte
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		e.StatusCode = resp.StatusCode
+		e.Status = resp.Status
+		e.ResponseHeader = redactHeader(resp.Header)
+		e.ResponseBody = redactBody(resp.Header.Get("Content-Type"), body)
+		if err != nil {
+			resp = nil
+		}
+	}
+	e.Duration = time.Since(e.Started)
+	e.Err = err
+
+	for _, trace := range t.tracers {
+		trace(e)
+	}
+	return resp, err
+}
+
+// DebugTracer returns a Tracer writing each exchange to logger
+func DebugTracer(logger Logger) Tracer {
+	return func(e *Exchange) {
+		logger("[ DEBUG ] --> %s %s\n", e.Method, e.URL)
+		logHeader(logger, e.RequestHeader)
+		logBody(logger, e.RequestBody)
+		if e.Err != nil {
+			logger("[ DEBUG ] <-- %s %s failed after %s: %s\n", e.Method, e.URL, e.Duration.Round(time.Millisecond), e.Err)
+			return
+		}
+		logger("[ DEBUG ] <-- %s %s %s (%s)\n", e.Status, e.Method, e.URL, e.Duration.Round(time.Millisecond))
+		logHeader(logger, e.ResponseHeader)
+		logBody(logger, e.ResponseBody)
+	}
+}
+
+func logHeader(logger Logger, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		logger("[ DEBUG ]     %s: %s\n", name, strings.Join(header[name], ", "))
+	}
+}
+
+func logBody(logger Logger, body []byte) {
+	if len(body) > 0 {
+		logger("[ DEBUG ]     %s\n", body)
+	}
+}
+
+func redactHeader(header http.Header) http.Header {
+	res := make(http.Header, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[strings.ToLower(name)] {
+			values = []string{redacted}
+		}
+		res[name] = append([]string(nil), values...)
+	}
+	return res
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+	if c.RawQuery != "" {
+		c.RawQuery = redactValues(c.Query()).Encode()
+	}
+	return c.String()
+}
+
+func redactValues(values url.Values) url.Values {
+	for name := range values {
+		if sensitiveFields[strings.ToLower(name)] {
+			values[name] = []string{redacted}
+		}
+	}
+	return values
+}
+
+// redactBody redacts JSON and form bodies, other bodies are returned as they are
+func redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []byte(redacted)
+		}
+		return []byte(redactValues(values).Encode())
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return body
+	}
+	res, err := json.Marshal(redactJSON(obj))
+	if err != nil {
+		return []byte(fmt.Sprintf("%q", redacted))
+	}
+	return res
+}
+
+func redactJSON(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if sensitiveFields[strings.ToLower(name)] {
+				v[name] = redacted
+			} else {
+				v[name] = redactJSON(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return obj
+}
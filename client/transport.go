@@ -0,0 +1,90 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+//TransportConfig describes how to reach the network from behind a corporate proxy
+type TransportConfig struct {
+	// Proxy is the URL of the proxy all requests go through, the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY variables apply when empty
+	Proxy string
+	// CABundle is a PEM file of certificate authorities trusted in
+	// addition to the system ones, e.g. the one of a TLS-intercepting proxy
+	CABundle string
+	// ClientCert and ClientKey are PEM files presented for mutual TLS
+	ClientCert string
+	ClientKey  string
+	// Insecure disables server certificate verification
+	Insecure bool
+}
+
+//IsZero reports whether the config leaves the default transport unchanged
+func (c TransportConfig) IsZero() bool {
+	return c == TransportConfig{}
+}
+
+//NewTransport returns a copy of the default transport configured by cfg
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	tlsConfig := transport.TLSClientConfig
+
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := ioutil.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %s", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	tlsConfig.InsecureSkipVerify = cfg.Insecure
+	return transport, nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "transport")
+	defer os.RemoveAll(dir)
+	bundle := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)
+
+	_, err := (&http.Client{}).Get(server.URL)
+	assert.NotNil(t, err, "the test server certificate should not be trusted by default.")
+
+	transport, err := NewTransport(TransportConfig{CABundle: bundle})
+	assert.Nil(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	if resp != nil {
+		resp.Body.Close()
+	}
+}
+
+func TestNewTransportInsecure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := NewTransport(TransportConfig{Insecure: true})
+	assert.Nil(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	if resp != nil {
+		resp.Body.Close()
+	}
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	transport, err := NewTransport(TransportConfig{Proxy: "http://proxy.example.com:3128"})
+	assert.Nil(t, err)
+	req, _ := http.NewRequest("GET", "https://app.securestate.vmware.com/api", nil)
+	proxyURL, _ := transport.Proxy(req)
+	assert.Equal(t, "proxy.example.com:3128", proxyURL.Host)
+
+	_, err = NewTransport(TransportConfig{Proxy: "not a url"})
+	assert.NotNil(t, err)
+}
+
+func TestNewTransportInvalidFiles(t *testing.T) {
+	_, err := NewTransport(TransportConfig{CABundle: "/does/not/exist.pem"})
+	assert.NotNil(t, err)
+
+	_, err = NewTransport(TransportConfig{ClientCert: "cert.pem"})
+	assert.NotNil(t, err, "a client certificate without key should be rejected.")
+}
@@ -0,0 +1,300 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// continuationToken is the response field the API pages its results with
+const continuationToken = "continuationToken"
+
+type apiCmd struct {
+	out      io.Writer
+	in       io.Reader
+	client   command.Interface
+	method   string
+	path     string
+	fields   []string
+	headers  []string
+	input    string
+	paginate bool
+}
+
+func newAPICmd(client command.Interface, out io.Writer) *cobra.Command {
+	api := &apiCmd{
+		out:    out,
+		in:     os.Stdin,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:               content.CmdAPIUse,
+		Short:             content.CmdAPIShort,
+		Long:              content.CmdAPILong,
+		Example:           content.CmdAPIExample,
+		PersistentPreRunE: setupCoreoConfig,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New(content.ErrorAPIArgs)
+			}
+			api.method = strings.ToUpper(args[0])
+			api.path = strings.TrimLeft(args[1], "/")
+
+			if api.client == nil {
+				api.client = newCoreoClient()
+			}
+
+			return api.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVarP(&api.fields, content.CmdFlagFieldLong, content.CmdFlagFieldShort, nil, content.CmdFlagFieldDescription)
+	f.StringArrayVarP(&api.headers, content.CmdFlagHeaderLong, content.CmdFlagHeaderShort, nil, content.CmdFlagHeaderDescription)
+	f.StringVarP(&api.input, content.CmdFlagInputLong, "", "", content.CmdFlagInputDescription)
+	f.BoolVarP(&api.paginate, content.CmdFlagPaginateLong, "", false, content.CmdFlagPaginateDescription)
+
+	return cmd
+}
+
+func (t *apiCmd) run() error {
+	header, err := parseHeaders(t.headers)
+	if err != nil {
+		return err
+	}
+
+	path, body, err := t.buildRequest()
+	if err != nil {
+		return err
+	}
+
+	var pages [][]byte
+	// A server handing out a token twice would otherwise be paged forever
+	seen := map[string]bool{}
+	for {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		page, err := t.client.Call(commandCtx, t.method, path, header, reader)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, page)
+
+		token := nextPageToken(page)
+		if !t.paginate || token == "" || seen[token] {
+			break
+		}
+		seen[token] = true
+		if path, body, err = t.nextPage(path, body, token); err != nil {
+			return err
+		}
+	}
+
+	return printAPIResponse(t.out, mergePages(pages))
+}
+
+// buildRequest returns the path with its query and the body to send
+func (t *apiCmd) buildRequest() (string, []byte, error) {
+	var body []byte
+	if t.input != "" {
+		var err error
+		if t.input == "-" {
+			body, err = ioutil.ReadAll(t.in)
+		} else {
+			body, err = ioutil.ReadFile(t.input)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(t.fields) == 0 {
+		return t.path, body, nil
+	}
+
+	if body != nil || t.method == http.MethodGet || t.method == http.MethodDelete || t.method == http.MethodHead {
+		u, err := url.Parse(t.path)
+		if err != nil {
+			return "", nil, err
+		}
+		query := u.Query()
+		for _, field := range t.fields {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return "", nil, fmt.Errorf(content.ErrorInvalidField, field)
+			}
+			query.Add(kv[0], kv[1])
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), body, nil
+	}
+
+	obj := map[string]interface{}{}
+	for _, field := range t.fields {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return "", nil, fmt.Errorf(content.ErrorInvalidField, field)
+		}
+		setField(obj, strings.Split(kv[0], "."), kv[1])
+	}
+	body, err := json.Marshal(obj)
+	return t.path, body, err
+}
+
+// nextPage returns the request for the page after token. GET requests take it
+// as query parameter, others in the paginationInfo of their JSON body.
+func (t *apiCmd) nextPage(path string, body []byte, token string) (string, []byte, error) {
+	if t.method == http.MethodGet {
+		u, err := url.Parse(path)
+		if err != nil {
+			return "", nil, err
+		}
+		query := u.Query()
+		query.Set(continuationToken, token)
+		u.RawQuery = query.Encode()
+		return u.String(), body, nil
+	}
+
+	obj := map[string]interface{}{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return "", nil, errors.New(content.ErrorPaginateBody)
+		}
+	}
+	paginationInfo, ok := obj["paginationInfo"].(map[string]interface{})
+	if !ok {
+		paginationInfo = map[string]interface{}{}
+		obj["paginationInfo"] = paginationInfo
+	}
+	paginationInfo[continuationToken] = token
+	body, err := json.Marshal(obj)
+	return path, body, err
+}
+
+// setField sets obj[keys[0]][keys[1]]... to value, a key given twice becomes a list
+func setField(obj map[string]interface{}, keys []string, value string) {
+	if len(keys) > 1 {
+		child, ok := obj[keys[0]].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			obj[keys[0]] = child
+		}
+		setField(child, keys[1:], value)
+		return
+	}
+
+	switch current := obj[keys[0]].(type) {
+	case nil:
+		obj[keys[0]] = value
+	case []interface{}:
+		obj[keys[0]] = append(current, value)
+	default:
+		obj[keys[0]] = []interface{}{current, value}
+	}
+}
+
+func parseHeaders(headers []string) (http.Header, error) {
+	header := http.Header{}
+	for _, h := range headers {
+		kv := strings.SplitN(h, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf(content.ErrorInvalidHeader, h)
+		}
+		header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+	return header, nil
+}
+
+// nextPageToken returns the continuationToken of a JSON object response
+func nextPageToken(page []byte) string {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(page, &obj); err != nil {
+		return ""
+	}
+	token, _ := obj[continuationToken].(string)
+	return token
+}
+
+// mergePages concatenates paged responses, either JSON arrays or objects with
+// a results array, into the first one
+func mergePages(pages [][]byte) []byte {
+	if len(pages) == 1 {
+		return pages[0]
+	}
+
+	var first interface{}
+	if err := json.Unmarshal(pages[0], &first); err != nil {
+		return bytes.Join(pages, []byte("\n"))
+	}
+
+	var results []interface{}
+	for _, page := range pages {
+		var obj interface{}
+		if err := json.Unmarshal(page, &obj); err != nil {
+			return bytes.Join(pages, []byte("\n"))
+		}
+		switch v := obj.(type) {
+		case []interface{}:
+			results = append(results, v...)
+		case map[string]interface{}:
+			items, _ := v["results"].([]interface{})
+			results = append(results, items...)
+		}
+	}
+
+	var merged interface{} = results
+	if obj, ok := first.(map[string]interface{}); ok {
+		obj["results"] = results
+		delete(obj, continuationToken)
+		merged = obj
+	}
+	res, err := json.Marshal(merged)
+	if err != nil {
+		return bytes.Join(pages, []byte("\n"))
+	}
+	return res
+}
+
+// printAPIResponse pretty prints JSON responses and copies any other as it is
+func printAPIResponse(out io.Writer, body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, body, "", "  "); err != nil {
+		_, err = out.Write(body)
+		return err
+	}
+	buf.WriteString("\n")
+	_, err := buf.WriteTo(out)
+	return err
+}
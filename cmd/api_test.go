@@ -0,0 +1,100 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runAPICmd(frc *fakeReleaseClient, args []string, flags ...string) (string, error) {
+	var buf bytes.Buffer
+	cmd := newAPICmd(frc, &buf)
+	cmd.ParseFlags(flags)
+	err := cmd.RunE(cmd, args)
+	return buf.String(), err
+}
+
+func TestAPICmdGet(t *testing.T) {
+	frc := &fakeReleaseClient{responses: [][]byte{[]byte(`[{"_id":"c1"}]`)}}
+	out, err := runAPICmd(frc, []string{"get", "/v1/cloudaccounts"}, "-f", "provider=AWS", "-H", "Accept: application/json")
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  {\n    \"_id\": \"c1\"\n  }\n]\n", out)
+
+	assert.Equal(t, 1, len(frc.calls))
+	assert.Equal(t, "GET", frc.calls[0].method)
+	assert.Equal(t, "v1/cloudaccounts?provider=AWS", frc.calls[0].path)
+	assert.Equal(t, "application/json", frc.calls[0].header.Get("Accept"))
+	assert.Equal(t, "", frc.calls[0].body)
+}
+
+func TestAPICmdPostFields(t *testing.T) {
+	frc := &fakeReleaseClient{responses: [][]byte{[]byte(`not json`)}}
+	out, err := runAPICmd(frc, []string{"POST", "v2/findings/query"}, "-f", "filters.levels=High", "-f", "filters.levels=Medium", "-f", "name=x")
+	assert.Nil(t, err)
+	assert.Equal(t, "not json", out)
+	assert.Equal(t, `{"filters":{"levels":["High","Medium"]},"name":"x"}`, frc.calls[0].body)
+}
+
+func TestAPICmdPaginate(t *testing.T) {
+	frc := &fakeReleaseClient{responses: [][]byte{
+		[]byte(`{"totalCount":3,"results":[{"id":1},{"id":2}],"continuationToken":"t1"}`),
+		[]byte(`{"totalCount":3,"results":[{"id":3}]}`),
+	}}
+	api := &apiCmd{client: frc, out: &bytes.Buffer{}, in: strings.NewReader(`{"filters":{}}`), method: "POST", path: "v2/findings/query", input: "-", paginate: true}
+	assert.Nil(t, api.run())
+
+	assert.Equal(t, 2, len(frc.calls))
+	assert.Equal(t, `{"filters":{}}`, frc.calls[0].body)
+	assert.Equal(t, `{"filters":{},"paginationInfo":{"continuationToken":"t1"}}`, frc.calls[1].body)
+	out := api.out.(*bytes.Buffer).String()
+	assert.Contains(t, out, `"id": 3`)
+	assert.NotContains(t, out, continuationToken)
+}
+
+func TestAPICmdPaginateGet(t *testing.T) {
+	frc := &fakeReleaseClient{responses: [][]byte{
+		[]byte(`{"results":[1],"continuationToken":"t1"}`),
+		[]byte(`{"results":[2]}`),
+	}}
+	_, err := runAPICmd(frc, []string{"GET", "v1/things"}, "--paginate")
+	assert.Nil(t, err)
+	assert.Equal(t, "v1/things?continuationToken=t1", frc.calls[1].path)
+}
+
+func TestAPICmdPaginateRepeatedToken(t *testing.T) {
+	frc := &fakeReleaseClient{responses: [][]byte{
+		[]byte(`{"results":[1],"continuationToken":"t1"}`),
+		[]byte(`{"results":[2],"continuationToken":"t1"}`),
+		[]byte(`{"results":[3]}`),
+	}}
+	_, err := runAPICmd(frc, []string{"GET", "v1/things"}, "--paginate")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(frc.calls), "paging stops when a token comes back")
+}
+
+func TestAPICmdInvalidInput(t *testing.T) {
+	_, err := runAPICmd(&fakeReleaseClient{}, []string{"GET"})
+	assert.NotNil(t, err, "a path should be required")
+
+	_, err = runAPICmd(&fakeReleaseClient{}, []string{"GET", "v1/things"}, "-f", "novalue")
+	assert.NotNil(t, err, "fields without value should be rejected")
+
+	_, err = runAPICmd(&fakeReleaseClient{}, []string{"GET", "v1/things"}, "-H", "NoColon")
+	assert.NotNil(t, err, "headers without value should be rejected")
+}
@@ -0,0 +1,59 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+)
+
+// replayAPIKey stands in for the API key when replaying, the recorded CSP
+// token exchange answers whatever key is sent
+const replayAPIKey = "replay"
+
+var (
+	// recorder saves the session when --record is set
+	recorder *client.Cassette
+	// player answers API requests when --replay is set
+	player *client.Cassette
+)
+
+// setupCassette opens the --record or --replay directory
+func setupCassette() error {
+	var err error
+	switch {
+	case recordDir != "" && replayDir != "":
+		return errors.New(content.ErrorRecordAndReplay)
+	case recordDir != "" && recorder == nil:
+		recorder, err = client.NewRecorder(recordDir)
+	case replayDir != "" && player == nil:
+		player, err = client.LoadCassette(replayDir)
+	}
+	return err
+}
+
+// checkRecording reports interactions that could not be saved for --record
+func checkRecording() {
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, content.ErrorWritingCassette, err)
+	}
+}
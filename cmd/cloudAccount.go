@@ -17,12 +17,13 @@ package main
 import (
 	"fmt"
 	"io"
+	"path"
 
+	"github.com/CloudCoreo/cli/client"
 	"github.com/CloudCoreo/cli/pkg/command"
 
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/cmd/util"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
@@ -40,13 +41,23 @@ func newCloudAccountCmd(out io.Writer) *cobra.Command {
 	cmd.AddCommand(newCloudCreateCmd(nil, out))
 	cmd.AddCommand(newCloudUpdateCmd(nil, out))
 	cmd.AddCommand(newCloudTestCmd(nil, out))
+	cmd.AddCommand(newCloudImportCmd(nil, out))
+	cmd.AddCommand(newCloudExportCmd(nil, out))
 
 	return cmd
 }
 
 type cloudListCmd struct {
-	out    io.Writer
-	client command.Interface
+	out          io.Writer
+	client       command.Interface
+	providers    []string
+	environments []string
+	tags         []string
+	name         string
+	valid        bool
+	invalid      bool
+	draft        bool
+	draftSet     bool
 }
 
 func newCloudListCmd(client command.Interface, out io.Writer) *cobra.Command {
@@ -60,25 +71,74 @@ func newCloudListCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Short: content.CmdCloudListShort,
 		Long:  content.CmdCloudListLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			cloudList.draftSet = cmd.Flags().Changed(content.CmdFlagDraftLong)
 
 			if cloudList.client == nil {
-				cloudList.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudList.client = newCoreoClient()
 			}
 
 			return cloudList.run()
 		},
 	}
 
+	f := cmd.Flags()
+	f.StringSliceVar(&cloudList.providers, content.CmdFlagProvider, nil, content.CmdFlagCloudListProviderDescription)
+	f.StringSliceVarP(&cloudList.environments, content.CmdFlagEnvironmentLong, content.CmdFlagEnvironmentShort, nil, content.CmdFlagCloudListEnvironmentDescription)
+	f.StringSliceVar(&cloudList.tags, content.CmdFlagTagLong, nil, content.CmdFlagCloudListTagDescription)
+	f.StringVar(&cloudList.name, content.CmdFlagNameLong, "", content.CmdFlagCloudListNameDescription)
+	f.BoolVar(&cloudList.valid, content.CmdFlagValidLong, false, content.CmdFlagValidDescription)
+	f.BoolVar(&cloudList.invalid, content.CmdFlagInvalidLong, false, content.CmdFlagInvalidDescription)
+	f.BoolVar(&cloudList.draft, content.CmdFlagDraftLong, false, content.CmdFlagCloudListDraftDescription)
+
 	return cmd
 }
 
+// filter validates the flags and returns them in the casing the API expects
+func (t *cloudListCmd) filter() (client.CloudAccountFilter, error) {
+	providers, err := util.NormalizeValues(t.providers, []string{"AWS", "Azure"}, content.ErrorInvalidFindingProvider)
+	if err != nil {
+		return client.CloudAccountFilter{}, err
+	}
+	environments, err := util.NormalizeValues(t.environments, []string{"Production", "Staging", "Development", "Test"}, content.ErrorInvalidEnvironment)
+	if err != nil {
+		return client.CloudAccountFilter{}, err
+	}
+	if _, err := path.Match(t.name, ""); err != nil {
+		return client.CloudAccountFilter{}, fmt.Errorf(content.ErrorInvalidNameGlob, t.name)
+	}
+	if t.valid && t.invalid {
+		return client.CloudAccountFilter{}, fmt.Errorf(content.ErrorValidAndInvalid)
+	}
+
+	filter := client.CloudAccountFilter{
+		Providers:    providers,
+		Environments: environments,
+		Tags:         t.tags,
+		Name:         t.name,
+	}
+	if t.valid || t.invalid {
+		filter.IsValid = &t.valid
+	}
+	if t.draftSet {
+		filter.IsDraft = &t.draft
+	}
+	return filter, nil
+}
+
 func (t *cloudListCmd) run() error {
-	clouds, err := t.client.ListCloudAccounts()
+	filter, err := t.filter()
 	if err != nil {
 		return err
 	}
+	clouds, err := t.client.QueryCloudAccounts(commandCtx, filter)
+	if err != nil {
+		return err
+	}
+
+	if len(clouds) == 0 && !jsonFormat {
+		fmt.Fprintln(t.out, content.InfoNoCloudAccounts)
+		return nil
+	}
 
 	b := make([]interface{}, len(clouds))
 	for i := range clouds {
@@ -104,9 +164,9 @@ func (t *cloudListCmd) run() error {
 }
 
 type cloudTestCmd struct {
-	out     io.Writer
-	client  command.Interface
-	cloudID string
+	out    io.Writer
+	client command.Interface
+	cloudFlags
 }
 
 func newCloudTestCmd(client command.Interface, out io.Writer) *cobra.Command {
@@ -120,13 +180,11 @@ func newCloudTestCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Short: content.CmdCloudTestShort,
 		Long:  content.CmdCloudTestLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := util.CheckCloudShowOrDeleteFlag(cloudTest.cloudID, verbose); err != nil {
-				return err
-			}
 			if cloudTest.client == nil {
-				cloudTest.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudTest.client = newCoreoClient()
+			}
+			if err := cloudTest.resolveCloud(cloudTest.client); err != nil {
+				return err
 			}
 
 			return cloudTest.run()
@@ -135,13 +193,13 @@ func newCloudTestCmd(client command.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 
-	f.StringVarP(&cloudTest.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	cloudTest.addFlags(f)
 
 	return cmd
 }
 
 func (t *cloudTestCmd) run() error {
-	res, err := t.client.ReValidateRole(t.cloudID)
+	res, err := t.client.ReValidateRole(commandCtx, t.cloudID)
 	if err != nil {
 		return err
 	}
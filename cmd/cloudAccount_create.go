@@ -26,7 +26,6 @@ import (
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/cmd/util"
 	"github.com/CloudCoreo/cli/pkg/command"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
@@ -81,9 +80,7 @@ func newCloudCreateCmd(client command.Interface, out io.Writer) *cobra.Command {
 			}
 
 			if cloudCreate.client == nil {
-				cloudCreate.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudCreate.client = newCoreoClient()
 			}
 
 			if cloudCreate.cloud == nil {
@@ -140,25 +137,32 @@ func (t *cloudCreateCmd) run() error {
 		Tags:           t.tags,
 	}
 	if t.roleName != "" {
-		info, err := t.client.GetRoleCreationInfo(input)
+		info, err := t.client.GetRoleCreationInfo(commandCtx, input)
 		if err != nil {
 			return err
 		}
-		arn, externalID, err := t.cloud.CreateNewRole(info)
-		time.Sleep(10 * time.Second)
+		arn, externalID, err := t.cloud.CreateNewRole(commandCtx, info)
 		if err != nil {
 			return err
 		}
+		if err := sleepOrCancel(10 * time.Second); err != nil {
+			ctx, cancel := rollbackContext()
+			t.cloud.DeleteRole(ctx, t.roleName)
+			cancel()
+			return err
+		}
 
 		input.RoleArn = arn
 		input.ExternalID = externalID
 	}
 
-	cloud, err := t.client.CreateCloudAccount(input)
+	cloud, err := t.client.CreateCloudAccount(commandCtx, input)
 	if err != nil {
 		if t.roleName != "" {
 			fmt.Println("Cloud account creation failed! Will delete created role.")
-			t.cloud.DeleteRole(t.roleName)
+			ctx, cancel := rollbackContext()
+			t.cloud.DeleteRole(ctx, t.roleName)
+			cancel()
 		}
 		return err
 	}
@@ -16,12 +16,15 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/CloudCoreo/cli/client"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCloudAccountCreateCmd(t *testing.T) {
@@ -95,3 +98,21 @@ func TestCloudAccountCreateCmd(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestCloudAccountCreateCmdCancelledWhileRoleSettles(t *testing.T) {
+	defer func(ctx context.Context) { commandCtx = ctx }(commandCtx)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	commandCtx = ctx
+
+	cloud := &roleCloudProvider{fakeCloudProvider: &fakeCloudProvider{arn: "arn:aws:iam::123456789012:role/vss", externalID: "ext"}}
+	clt := &importClient{fakeReleaseClient: &fakeReleaseClient{}}
+	cloudCreate := &cloudCreateCmd{out: &bytes.Buffer{}, client: clt, cloud: cloud, resourceName: "prod", roleName: "vss", provider: "AWS"}
+
+	start := time.Now()
+	err := cloudCreate.run()
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < time.Second, "the wait for the new role is cancelled")
+	assert.Equal(t, []string{"vss"}, cloud.deleted, "the new role is rolled back")
+	assert.Empty(t, clt.created)
+}
@@ -25,16 +25,14 @@ import (
 	"fmt"
 
 	"github.com/CloudCoreo/cli/cmd/content"
-	"github.com/CloudCoreo/cli/cmd/util"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
 type cloudDeleteCmd struct {
-	out            io.Writer
-	client         command.Interface
-	cloud          command.CloudProvider
-	cloudID        string
+	out    io.Writer
+	client command.Interface
+	cloud  command.CloudProvider
+	cloudFlags
 	deleteRole     bool
 	awsProfile     string
 	awsProfilePath string
@@ -52,14 +50,11 @@ func newCloudDeleteCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Long:  content.CmdCloudDeleteLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if err := util.CheckCloudShowOrDeleteFlag(cloudDelete.cloudID, verbose); err != nil {
-				return err
-			}
-
 			if cloudDelete.client == nil {
-				cloudDelete.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudDelete.client = newCoreoClient()
+			}
+			if err := cloudDelete.resolveCloud(cloudDelete.client); err != nil {
+				return err
 			}
 
 			if cloudDelete.deleteRole && (cloudDelete.cloud == nil) {
@@ -76,7 +71,7 @@ func newCloudDeleteCmd(client command.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 
-	f.StringVarP(&cloudDelete.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	cloudDelete.addFlags(f)
 	f.BoolVarP(&cloudDelete.deleteRole, content.CmdFlagDeleteRole, "", false, content.CmdFLagDeleteRoleDescription)
 	f.StringVarP(&cloudDelete.awsProfile, content.CmdFlagAwsProfile, "", "", content.CmdFlagAwsProfileDescription)
 	f.StringVarP(&cloudDelete.awsProfilePath, content.CmdFlagAwsProfilePath, "", "", content.CmdFlagAwsProfilePathDescription)
@@ -87,7 +82,7 @@ func newCloudDeleteCmd(client command.Interface, out io.Writer) *cobra.Command {
 func (t *cloudDeleteCmd) run() error {
 	var roleName string
 	if t.deleteRole {
-		cloud, err := t.client.ShowCloudAccountByID(t.cloudID)
+		cloud, err := t.client.ShowCloudAccountByID(commandCtx, t.cloudID)
 		if err != nil {
 			return err
 		}
@@ -95,10 +90,10 @@ func (t *cloudDeleteCmd) run() error {
 		roleNames := strings.Split(cloud.Arn, "/")
 		roleName = roleNames[len(roleNames)-1]
 
-		t.cloud.DeleteRole(roleName)
+		t.cloud.DeleteRole(commandCtx, roleName)
 	}
 
-	err := t.client.DeleteCloudAccountByID(t.cloudID)
+	err := t.client.DeleteCloudAccountByID(commandCtx, t.cloudID)
 	if err != nil {
 		return err
 	}
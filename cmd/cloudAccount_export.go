@@ -0,0 +1,88 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+type cloudExportCmd struct {
+	out    io.Writer
+	client command.Interface
+	file   string
+	format string
+}
+
+func newCloudExportCmd(client command.Interface, out io.Writer) *cobra.Command {
+	cloudExport := &cloudExportCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdCloudExportUse,
+		Short:   content.CmdCloudExportShort,
+		Long:    content.CmdCloudExportLong,
+		Example: content.CmdCloudExportExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				cloudExport.file = args[0]
+			}
+
+			if cloudExport.client == nil {
+				cloudExport.client = newCoreoClient()
+			}
+
+			return cloudExport.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&cloudExport.format, content.CmdFlagFormatLong, "", manifest.FormatYAML, content.CmdFlagCloudExportFormatDescription)
+
+	return cmd
+}
+
+func (t *cloudExportCmd) run() error {
+	format := strings.ToLower(t.format)
+	if format != manifest.FormatYAML && format != manifest.FormatCSV {
+		return fmt.Errorf(content.ErrorInvalidManifestFormat, t.format)
+	}
+
+	clouds, err := t.client.ListCloudAccounts(commandCtx)
+	if err != nil {
+		return err
+	}
+	m := manifest.Export(clouds)
+
+	if t.file == "" {
+		return m.Write(t.out, format)
+	}
+	if err := m.Save(t.file); err != nil {
+		return err
+	}
+	fmt.Fprintf(t.out, content.InfoCloudExported, len(m.CloudAccounts), t.file)
+	if placeholders := m.Placeholders(); len(placeholders) > 0 {
+		fmt.Fprintf(t.out, content.InfoCloudExportPlaceholders, strings.Join(placeholders, "\n  "))
+	}
+	return nil
+}
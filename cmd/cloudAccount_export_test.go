@@ -0,0 +1,72 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/manifest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloudExportCmd(t *testing.T) {
+	path, cleanup := importManifest(t, "unused.yaml", "")
+	defer cleanup()
+	path = filepath.Join(filepath.Dir(path), "accounts.csv")
+
+	clt := &fakeReleaseClient{cloudAccounts: []*client.CloudAccount{
+		{ID: "id-azure", CloudInfo: client.CloudInfo{Name: "azure", Provider: "Azure", SubscriptionID: "sub", KeyValue: "secret"}},
+	}}
+	var buf bytes.Buffer
+	cloudExport := &cloudExportCmd{out: &buf, client: clt, file: path, format: manifest.FormatYAML}
+	assert.Nil(t, cloudExport.run())
+	assert.Equal(t, "Exported 1 cloud accounts to "+path+"\nSet these environment variables to the secrets of the accounts before importing it:\n  VSS_AZURE_KEY_VALUE\n", buf.String())
+
+	m, err := manifest.Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "${VSS_AZURE_KEY_VALUE}", m.CloudAccounts[0].KeyValue)
+	assert.Equal(t, "sub", m.CloudAccounts[0].SubscriptionID)
+}
+
+func TestCloudExportCmdStdout(t *testing.T) {
+	var buf bytes.Buffer
+	clt := &fakeReleaseClient{}
+	cloudExport := &cloudExportCmd{out: &buf, client: clt, format: "YAML"}
+	assert.Nil(t, cloudExport.run())
+	assert.Equal(t, "cloudAccounts: []\n", buf.String())
+
+	cloudExport.format = "json"
+	assert.EqualError(t, cloudExport.run(), "Invalid format json, use yaml or csv")
+}
+
+func TestCloudExportCmdDuplicateNames(t *testing.T) {
+	var buf bytes.Buffer
+	clt := &fakeReleaseClient{cloudAccounts: []*client.CloudAccount{
+		{ID: "id-1", CloudInfo: client.CloudInfo{Name: "prod", Provider: "AWS", Environment: "Production"}},
+		{ID: "id-2", CloudInfo: client.CloudInfo{Name: "prod", Provider: "AWS", Environment: "Staging"}},
+	}}
+	cloudExport := &cloudExportCmd{out: &buf, client: clt, format: manifest.FormatYAML}
+	assert.Nil(t, cloudExport.run())
+
+	m, err := manifest.Parse(buf.Bytes(), manifest.FormatYAML)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(m.CloudAccounts), "accounts sharing a name are all exported") {
+		assert.Equal(t, "Production", m.CloudAccounts[0].Environment)
+		assert.Equal(t, "Staging", m.CloudAccounts[1].Environment)
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/aws"
+	"github.com/CloudCoreo/cli/pkg/azure"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// Outcomes of importing a row
+const (
+	importCreated = "created"
+	importExists  = "exists"
+	importFailed  = "failed"
+	importInvalid = "invalid"
+
+	eventStreamSetUp   = "set up"
+	eventStreamFailed  = "failed"
+	eventStreamSkipped = "skipped"
+)
+
+// rolePropagationDelay is how long a new role is given to become usable
+// before the cloud account is added with it
+var rolePropagationDelay = 10 * time.Second
+
+// importResult is the outcome of importing one row of a manifest
+type importResult struct {
+	Row         int    `json:"row"`
+	Name        string `json:"name"`
+	Provider    string `json:"provider"`
+	CloudID     string `json:"cloudId,omitempty"`
+	Status      string `json:"status"`
+	EventStream string `json:"eventStream"`
+	Error       string `json:"error,omitempty"`
+}
+
+type cloudImportCmd struct {
+	out                 io.Writer
+	client              command.Interface
+	cloud               func(account *manifest.CloudAccount) command.CloudProvider
+	file                string
+	eventSetup          bool
+	ignoreMissingTrails bool
+	report              string
+}
+
+func newCloudImportCmd(client command.Interface, out io.Writer) *cobra.Command {
+	cloudImport := &cloudImportCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdCloudImportUse,
+		Short:   content.CmdCloudImportShort,
+		Long:    content.CmdCloudImportLong,
+		Example: content.CmdCloudImportExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cloudImport.file = args[0]
+
+			if cloudImport.client == nil {
+				cloudImport.client = newCoreoClient()
+			}
+
+			if cloudImport.cloud == nil {
+				cloudImport.cloud = cloudImport.newCloudProvider
+			}
+
+			return cloudImport.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVarP(&cloudImport.eventSetup, content.CmdFlagEventSetupLong, "", false, content.CmdFlagEventSetupDescription)
+	f.BoolVarP(&cloudImport.ignoreMissingTrails, content.CmdFlagIgnoreMissingTrails, "", false, content.CmdFlagIgnoreMissingTrailsDescription)
+	f.StringVarP(&cloudImport.report, content.CmdFlagReportLong, "", "", content.CmdFlagImportReportDescription)
+
+	return cmd
+}
+
+// newCloudProvider returns the service creating the role and event stream of
+// account, with the AWS profile or Azure auth file of its row
+func (t *cloudImportCmd) newCloudProvider(account *manifest.CloudAccount) command.CloudProvider {
+	if account.Provider == "Azure" {
+		region := account.Region
+		if region == "" {
+			region = "eastus"
+		}
+		return azure.NewService(&azure.NewServiceInput{AuthFile: account.AuthFile, Region: region})
+	}
+	return aws.NewService(&aws.NewServiceInput{
+		AwsProfile:          account.AwsProfile,
+		AwsProfilePath:      account.AwsProfilePath,
+		IgnoreMissingTrails: t.ignoreMissingTrails,
+	})
+}
+
+// listCloudAccountsByName returns the cloud accounts of the team by name
+func listCloudAccountsByName(clt command.Interface) (map[string]*client.CloudAccount, error) {
+	clouds, err := clt.ListCloudAccounts(commandCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*client.CloudAccount{}
+	for _, cloud := range clouds {
+		byName[cloud.Name] = cloud
+	}
+	return byName, nil
+}
+
+func (t *cloudImportCmd) run() error {
+	m, err := manifest.Load(t.file)
+	if err != nil {
+		return err
+	}
+
+	existing, err := listCloudAccountsByName(t.client)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*importResult, len(m.CloudAccounts))
+	rows := map[string]int{}
+	failed := 0
+	for i, account := range m.CloudAccounts {
+		if !jsonFormat {
+			fmt.Fprintf(t.out, content.InfoImportingCloudAccount, account.Name, i+1, len(m.CloudAccounts))
+		}
+
+		if row, ok := rows[account.Name]; ok && account.Name != "" {
+			results[i] = &importResult{Name: account.Name, Provider: account.Provider, Status: importInvalid,
+				EventStream: eventStreamSkipped, Error: fmt.Sprintf(content.ErrorDuplicateCloudAccountName, row)}
+		} else {
+			rows[account.Name] = i + 1
+			results[i] = t.importAccount(account, existing)
+		}
+		results[i].Row = i + 1
+		if results[i].Error != "" {
+			failed++
+		}
+	}
+
+	t.print(results)
+	if t.report != "" {
+		if err := writeImportReport(t.report, results); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(content.ErrorImportFailed, failed, len(results))
+	}
+	return nil
+}
+
+// importAccount adds account unless the team has one of the same name, and
+// sets up its event stream when asked to
+func (t *cloudImportCmd) importAccount(account *manifest.CloudAccount, existing map[string]*client.CloudAccount) *importResult {
+	result := &importResult{Name: account.Name, Provider: account.Provider, EventStream: eventStreamSkipped}
+	if err := checkManifestAccount(account); err != nil {
+		result.Status = importInvalid
+		result.Error = err.Error()
+		return result
+	}
+	result.Provider = account.Provider
+
+	if cloud, ok := existing[account.Name]; ok {
+		result.Status = importExists
+		result.CloudID = cloud.ID
+	} else {
+		cloud, err := t.create(account)
+		if err != nil {
+			result.Status = importFailed
+			result.Error = err.Error()
+			return result
+		}
+		result.Status = importCreated
+		result.CloudID = cloud.ID
+	}
+
+	if t.eventSetup {
+		result.EventStream = eventStreamSetUp
+		if err := t.setupEventStream(account, result.CloudID); err != nil {
+			result.EventStream = eventStreamFailed
+			result.Error = err.Error()
+		}
+	}
+	return result
+}
+
+// checkManifestAccount checks a row like cloud add checks its flags, after
+// normalizing the provider and expanding environment variables in secrets
+func checkManifestAccount(account *manifest.CloudAccount) error {
+	if account.Name == "" {
+		return fmt.Errorf(content.ErrorCloudAccountNameRequired)
+	}
+
+	provider := account.Provider
+	if provider == "" {
+		provider = "AWS"
+	}
+	providers, err := util.NormalizeValues([]string{provider}, []string{"AWS", "Azure"}, content.ErrorInvalidFindingProvider)
+	if err != nil {
+		return err
+	}
+	account.Provider = providers[0]
+	account.KeyValue = os.ExpandEnv(account.KeyValue)
+	account.ExternalID = os.ExpandEnv(account.ExternalID)
+
+	if account.Provider == "Azure" {
+		return util.CheckCloudAddFlagsForAzure(account.KeyValue, account.ApplicationID, account.DirectoryID, account.SubscriptionID, account.Environment)
+	}
+	return util.CheckCloudAddFlagsForAWS(account.ExternalID, account.RoleArn, account.RoleName, account.Environment)
+}
+
+// create adds account, creating its role first when it names one
+func (t *cloudImportCmd) create(account *manifest.CloudAccount) (*client.CloudAccount, error) {
+	policy := account.Policy
+	if policy == "" {
+		policy = content.CmdFlagAwsPolicyDefault
+	}
+	input := &client.CreateCloudAccountInput{
+		CloudName:      account.Name,
+		RoleName:       account.RoleName,
+		ExternalID:     account.ExternalID,
+		RoleArn:        account.RoleArn,
+		Policy:         policy,
+		IsDraft:        account.IsDraft,
+		Email:          account.Email,
+		UserName:       account.UserName,
+		Environment:    account.Environment,
+		Provider:       account.Provider,
+		KeyValue:       account.KeyValue,
+		ApplicationID:  account.ApplicationID,
+		DirectoryID:    account.DirectoryID,
+		SubscriptionID: account.SubscriptionID,
+		Tags:           strings.Join(account.Tags, "|"),
+		Scan:           scanSettings(account, true),
+	}
+
+	var cloud command.CloudProvider
+	if account.Provider == "AWS" && account.RoleName != "" {
+		cloud = t.cloud(account)
+		info, err := t.client.GetRoleCreationInfo(commandCtx, input)
+		if err != nil {
+			return nil, err
+		}
+		arn, externalID, err := cloud.CreateNewRole(commandCtx, info)
+		if err != nil {
+			return nil, err
+		}
+		// A role created with the wrong AWS profile would add the wrong account
+		if account.AccountID != "" && !strings.HasPrefix(arn, "arn:aws:iam::"+account.AccountID+":") {
+			t.deleteRole(cloud, account.RoleName)
+			return nil, fmt.Errorf(content.ErrorRoleInOtherAccount, arn, account.AccountID)
+		}
+		if err := sleepOrCancel(rolePropagationDelay); err != nil {
+			t.deleteRole(cloud, account.RoleName)
+			return nil, err
+		}
+
+		input.RoleArn = arn
+		input.ExternalID = externalID
+	}
+
+	created, err := t.client.CreateCloudAccount(commandCtx, input)
+	if err != nil {
+		if cloud != nil {
+			t.deleteRole(cloud, account.RoleName)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+func (t *cloudImportCmd) deleteRole(cloud command.CloudProvider, roleName string) {
+	ctx, cancel := rollbackContext()
+	cloud.DeleteRole(ctx, roleName)
+	cancel()
+}
+
+func (t *cloudImportCmd) setupEventStream(account *manifest.CloudAccount, cloudID string) error {
+	config, err := t.client.GetEventStreamConfig(commandCtx, cloudID)
+	if err != nil {
+		return err
+	}
+	if config.Provider == "AWS" && len(config.Regions) == 0 {
+		return errors.New("No regions returned")
+	}
+	return t.cloud(account).SetupEventStream(commandCtx, config)
+}
+
+func (t *cloudImportCmd) print(results []*importResult) {
+	b := make([]interface{}, len(results))
+	for i := range results {
+		b[i] = results[i]
+	}
+
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"Row", "Name", "Provider", "CloudID", "Status", "EventStream", "Error"},
+		map[string]string{
+			"Row":         "Row",
+			"Name":        "Name",
+			"Provider":    "Provider",
+			"CloudID":     "Cloud Account ID",
+			"Status":      "Status",
+			"EventStream": "Event Stream",
+			"Error":       "Error",
+		},
+		jsonFormat,
+		verbose)
+}
+
+// writeImportReport writes results as JSON when path ends in .json, as CSV
+// otherwise
+func writeImportReport(path string, results []*importResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"row", "name", "provider", "cloudId", "status", "eventStream", "error"})
+	for _, r := range results {
+		w.Write([]string{strconv.Itoa(r.Row), r.Name, r.Provider, r.CloudID, r.Status, r.EventStream, r.Error})
+	}
+	w.Flush()
+	return w.Error()
+}
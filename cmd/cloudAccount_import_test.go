@@ -0,0 +1,194 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/manifest"
+	"github.com/stretchr/testify/assert"
+)
+
+// importClient records the cloud accounts created and event streams set up
+type importClient struct {
+	*fakeReleaseClient
+	created   []*client.CreateCloudAccountInput
+	failNames map[string]bool
+	events    []string
+}
+
+func (c *importClient) CreateCloudAccount(ctx context.Context, input *client.CreateCloudAccountInput) (*client.CloudAccount, error) {
+	if c.failNames[input.CloudName] {
+		return nil, client.NewError("invalid role")
+	}
+	c.created = append(c.created, input)
+	return &client.CloudAccount{ID: "id-" + input.CloudName, CloudInfo: client.CloudInfo{Name: input.CloudName}}, nil
+}
+
+func (c *importClient) GetEventStreamConfig(ctx context.Context, cloudID string) (*client.EventStreamConfig, error) {
+	c.events = append(c.events, cloudID)
+	config := &client.EventStreamConfig{}
+	config.Provider = "AWS"
+	config.Regions = []string{"us-east-1"}
+	return config, nil
+}
+
+// roleCloudProvider records the roles created and deleted
+type roleCloudProvider struct {
+	*fakeCloudProvider
+	profiles []string
+	deleted  []string
+}
+
+func (c *roleCloudProvider) DeleteRole(ctx context.Context, roleName string) {
+	c.deleted = append(c.deleted, roleName)
+}
+
+func importManifest(t *testing.T, name, body string) (string, func()) {
+	dir, err := ioutil.TempDir("", "vss-import")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, name)
+	assert.Nil(t, ioutil.WriteFile(path, []byte(body), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestCloudImportCmd(t *testing.T) {
+	defer func(delay time.Duration) { rolePropagationDelay = delay }(rolePropagationDelay)
+	rolePropagationDelay = 0
+	os.Setenv("VSS_TEST_AZURE_KEY", "secret")
+	defer os.Unsetenv("VSS_TEST_AZURE_KEY")
+
+	path, cleanup := importManifest(t, "accounts.csv", `name,provider,environment,accountId,awsProfile,roleName,roleArn,externalId,subscriptionId,applicationId,directoryId,keyValue
+existing,AWS,Production,,prod,securestate_role,,,,,,
+new-role,aws,Staging,123456789012,staging,securestate_role,,,,,,
+wrong-account,AWS,,999999999999,dev,securestate_role,,,,,,
+arn,AWS,,,,,arn:aws:iam::123456789012:role/vss,ext,,,,
+azure,Azure,Test,,,,,,sub,app,dir,${VSS_TEST_AZURE_KEY}
+rejected,AWS,,,,,arn:aws:iam::123456789012:role/vss,ext,,,,
+,AWS,,,,,,,,,,
+no-role,AWS,,,,,,,,,,
+arn,AWS,,,,,arn:aws:iam::123456789012:role/other,ext,,,,
+`)
+	defer cleanup()
+	report := filepath.Join(filepath.Dir(path), "report.json")
+
+	clt := &importClient{
+		fakeReleaseClient: &fakeReleaseClient{cloudAccounts: []*client.CloudAccount{{ID: "id-existing", CloudInfo: client.CloudInfo{Name: "existing"}}}},
+		failNames:         map[string]bool{"rejected": true},
+	}
+	cloud := &roleCloudProvider{fakeCloudProvider: &fakeCloudProvider{arn: "arn:aws:iam::123456789012:role/securestate_role", externalID: "generated"}}
+	var buf bytes.Buffer
+	cloudImport := &cloudImportCmd{
+		out:    &buf,
+		client: clt,
+		cloud: func(account *manifest.CloudAccount) command.CloudProvider {
+			cloud.profiles = append(cloud.profiles, account.AwsProfile)
+			return cloud
+		},
+		file:       path,
+		eventSetup: true,
+		report:     report,
+	}
+	assert.EqualError(t, cloudImport.run(), "5 of 9 rows failed")
+
+	assert.Equal(t, 3, len(clt.created))
+	assert.Equal(t, &client.CreateCloudAccountInput{
+		CloudName: "new-role", RoleName: "securestate_role", RoleArn: "arn:aws:iam::123456789012:role/securestate_role", ExternalID: "generated",
+		Policy: "arn:aws:iam::aws:policy/SecurityAudit", Environment: "Staging", Provider: "AWS",
+	}, clt.created[0])
+	assert.Equal(t, "arn:aws:iam::123456789012:role/vss", clt.created[1].RoleArn)
+	assert.Equal(t, "secret", clt.created[2].KeyValue)
+	assert.Equal(t, []string{"securestate_role"}, cloud.deleted, "the role in the wrong account is deleted")
+	assert.Equal(t, []string{"id-existing", "id-new-role", "id-arn", "id-azure"}, clt.events)
+
+	var results []*importResult
+	data, _ := ioutil.ReadFile(report)
+	assert.Nil(t, json.Unmarshal(data, &results))
+	statuses := make([]string, len(results))
+	for i, r := range results {
+		statuses[i] = fmt.Sprintf("%d %s %s %s", r.Row, r.Name, r.Status, r.EventStream)
+	}
+	assert.Equal(t, []string{
+		"1 existing exists set up",
+		"2 new-role created set up",
+		"3 wrong-account failed skipped",
+		"4 arn created set up",
+		"5 azure created set up",
+		"6 rejected failed skipped",
+		"7  invalid skipped",
+		"8 no-role invalid skipped",
+		"9 arn invalid skipped",
+	}, statuses)
+	assert.Equal(t, "AWS", results[1].Provider)
+	assert.Equal(t, "Name already used on row 4", results[8].Error)
+	assert.Contains(t, buf.String(), "Importing new-role (2/9)...")
+}
+
+func TestCloudImportCmdCancelledWhileRoleSettles(t *testing.T) {
+	defer func(ctx context.Context) { commandCtx = ctx }(commandCtx)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	commandCtx = ctx
+
+	cloud := &roleCloudProvider{fakeCloudProvider: &fakeCloudProvider{arn: "arn:aws:iam::123456789012:role/vss", externalID: "ext"}}
+	clt := &importClient{fakeReleaseClient: &fakeReleaseClient{}}
+	cloudImport := &cloudImportCmd{
+		out:    &bytes.Buffer{},
+		client: clt,
+		cloud:  func(account *manifest.CloudAccount) command.CloudProvider { return cloud },
+	}
+
+	start := time.Now()
+	_, err := cloudImport.create(&manifest.CloudAccount{Name: "prod", Provider: "AWS", RoleName: "vss"})
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < time.Second, "the wait for the new role is cancelled")
+	assert.Equal(t, []string{"vss"}, cloud.deleted, "the new role is rolled back")
+	assert.Empty(t, clt.created)
+}
+
+func TestCloudImportCmdIdempotent(t *testing.T) {
+	path, cleanup := importManifest(t, "accounts.yaml", `cloudAccounts:
+- name: prod
+  roleArn: arn:aws:iam::123456789012:role/vss
+  externalId: ext
+`)
+	defer cleanup()
+
+	clt := &importClient{fakeReleaseClient: &fakeReleaseClient{}}
+	var buf bytes.Buffer
+	cmd := newCloudImportCmd(clt, &buf)
+	assert.Nil(t, cmd.RunE(cmd, []string{path}))
+	assert.Equal(t, 1, len(clt.created))
+	assert.Empty(t, clt.events, "event streams are only set up with --event-setup")
+
+	clt.fakeReleaseClient = &fakeReleaseClient{cloudAccounts: []*client.CloudAccount{{ID: "id-prod", CloudInfo: client.CloudInfo{Name: "prod"}}}}
+	cmd = newCloudImportCmd(clt, &buf)
+	assert.Nil(t, cmd.RunE(cmd, []string{path}))
+	assert.Equal(t, 1, len(clt.created), "a second import adds nothing")
+
+	clt.fakeReleaseClient = &fakeReleaseClient{err: client.NewError("unauthorized")}
+	assert.EqualError(t, cmd.RunE(cmd, []string{path}), "unauthorized")
+}
@@ -0,0 +1,96 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/pflag"
+)
+
+// cloudFlags are the flags selecting the cloud account of the cloud and
+// event commands, either by Secure State ID or through --cloud
+type cloudFlags struct {
+	cloudID string
+	cloud   string
+}
+
+func (f *cloudFlags) addFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&f.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	flags.StringVarP(&f.cloud, content.CmdFlagCloudLong, "", "", content.CmdFlagCloudDescription)
+}
+
+// resolveCloud sets cloudID to the ID of the cloud account --cloud refers to
+// and checks that one of the flags was given
+func (f *cloudFlags) resolveCloud(clt command.Interface) error {
+	if f.cloud != "" {
+		if f.cloudID != "" {
+			return fmt.Errorf(content.ErrorCloudIDAndCloud)
+		}
+		cloud, err := resolveCloudAccount(clt, f.cloud)
+		if err != nil {
+			return err
+		}
+		f.cloudID = cloud.ID
+	}
+	return util.CheckCloudShowOrDeleteFlag(f.cloudID, verbose)
+}
+
+// resolveCloudAccount returns the cloud account whose Secure State ID, name,
+// AWS account ID or Azure subscription ID is ref. Names are compared
+// regardless of case when no name matches exactly.
+func resolveCloudAccount(clt command.Interface, ref string) (*client.CloudAccount, error) {
+	clouds, err := clt.ListCloudAccounts(commandCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cloud := range clouds {
+		if cloud.ID == ref {
+			return cloud, nil
+		}
+	}
+
+	var matches []*client.CloudAccount
+	for _, cloud := range clouds {
+		if cloud.Name == ref || cloud.AccountID == ref || cloud.SubscriptionID == ref {
+			matches = append(matches, cloud)
+		}
+	}
+	if len(matches) == 0 {
+		for _, cloud := range clouds {
+			if strings.EqualFold(cloud.Name, ref) {
+				matches = append(matches, cloud)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf(content.ErrorCloudNotFound, ref)
+	case 1:
+		return matches[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, cloud := range matches {
+		candidates[i] = fmt.Sprintf("%s (%s %s, --cloud-id %s)", cloud.Name, cloud.Provider, cloud.AccountID, cloud.ID)
+	}
+	return nil, fmt.Errorf(content.ErrorCloudAmbiguous, ref, len(matches), strings.Join(candidates, "\n  "))
+}
@@ -0,0 +1,93 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// revalidateClient records the cloud account re-validated
+type revalidateClient struct {
+	*fakeReleaseClient
+	cloudID string
+}
+
+func (c *revalidateClient) ReValidateRole(ctx context.Context, cloudID string) (*client.RoleReValidationResult, error) {
+	c.cloudID = cloudID
+	return &client.RoleReValidationResult{Message: "valid", IsValid: true}, nil
+}
+
+func resolveClouds() []*client.CloudAccount {
+	return []*client.CloudAccount{
+		{ID: "id-prod", AccountID: "123456789012", CloudInfo: client.CloudInfo{Name: "prod", Provider: "AWS"}},
+		{ID: "id-azure", AccountID: "sub-1", CloudInfo: client.CloudInfo{Name: "azure", Provider: "Azure", SubscriptionID: "sub-1"}},
+		{ID: "id-web", AccountID: "210987654321", CloudInfo: client.CloudInfo{Name: "web", Provider: "AWS"}},
+		{ID: "id-web2", AccountID: "210987654321", CloudInfo: client.CloudInfo{Name: "web-readonly", Provider: "AWS"}},
+	}
+}
+
+func TestResolveCloudAccount(t *testing.T) {
+	clt := &fakeReleaseClient{cloudAccounts: resolveClouds()}
+	for ref, id := range map[string]string{
+		"id-azure":     "id-azure",
+		"prod":         "id-prod",
+		"PROD":         "id-prod",
+		"123456789012": "id-prod",
+		"sub-1":        "id-azure",
+		"web":          "id-web",
+	} {
+		cloud, err := resolveCloudAccount(clt, ref)
+		assert.Nil(t, err, ref)
+		assert.Equal(t, id, cloud.ID, ref)
+	}
+
+	_, err := resolveCloudAccount(clt, "staging")
+	assert.EqualError(t, err, `No cloud account has the name, account ID, subscription ID or ID "staging"`)
+
+	_, err = resolveCloudAccount(clt, "210987654321")
+	assert.EqualError(t, err, `"210987654321" matches 2 cloud accounts, use --cloud-id with one of them:
+  web (AWS 210987654321, --cloud-id id-web)
+  web-readonly (AWS 210987654321, --cloud-id id-web2)`)
+
+	_, err = resolveCloudAccount(&fakeReleaseClient{err: client.NewError("unauthorized")}, "prod")
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestCloudFlags(t *testing.T) {
+	clt := &revalidateClient{fakeReleaseClient: &fakeReleaseClient{cloudAccounts: resolveClouds()}}
+	var buf bytes.Buffer
+
+	cmd := newCloudTestCmd(clt, &buf)
+	cmd.ParseFlags([]string{"--cloud", "sub-1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "id-azure", clt.cloudID)
+
+	cmd = newCloudTestCmd(clt, &buf)
+	cmd.ParseFlags([]string{"--cloud-id", "any-id"})
+	assert.Nil(t, cmd.RunE(cmd, nil), "--cloud-id is used as it is")
+	assert.Equal(t, "any-id", clt.cloudID)
+
+	cmd = newCloudTestCmd(clt, &buf)
+	cmd.ParseFlags([]string{"--cloud-id", "id-prod", "--cloud", "prod"})
+	assert.EqualError(t, cmd.RunE(cmd, nil), "Use either --cloud-id or --cloud")
+
+	cmd = newCloudTestCmd(clt, &buf)
+	assert.EqualError(t, cmd.RunE(cmd, nil), "Cloud Account ID is required for this command. Use flag '--cloud-id' or '--cloud'\n")
+}
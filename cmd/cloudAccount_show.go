@@ -21,14 +21,13 @@ import (
 
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/cmd/util"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
 type cloudShowCmd struct {
-	out     io.Writer
-	client  command.Interface
-	cloudID string
+	out    io.Writer
+	client command.Interface
+	cloudFlags
 }
 
 func newCloudShowCmd(client command.Interface, out io.Writer) *cobra.Command {
@@ -43,14 +42,11 @@ func newCloudShowCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Long:  content.CmdCloudShowLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if err := util.CheckCloudShowOrDeleteFlag(cloudShow.cloudID, verbose); err != nil {
-				return err
-			}
-
 			if cloudShow.client == nil {
-				cloudShow.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudShow.client = newCoreoClient()
+			}
+			if err := cloudShow.resolveCloud(cloudShow.client); err != nil {
+				return err
 			}
 
 			return cloudShow.run()
@@ -59,13 +55,13 @@ func newCloudShowCmd(client command.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 
-	f.StringVarP(&cloudShow.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	cloudShow.addFlags(f)
 
 	return cmd
 }
 
 func (t *cloudShowCmd) run() error {
-	cloud, err := t.client.ShowCloudAccountByID(t.cloudID)
+	cloud, err := t.client.ShowCloudAccountByID(commandCtx, t.cloudID)
 	if err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/CloudCoreo/cli/client"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCloudAccountListCmd(t *testing.T) {
@@ -88,3 +89,60 @@ func TestCloudAccountListCmd(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestCloudAccountListCmdFilters(t *testing.T) {
+	clouds := []*client.CloudAccount{
+		{ID: "1", CloudInfo: client.CloudInfo{Name: "prod-web", Provider: "AWS", Environment: "Production", Tags: []string{"pci"}, IsValid: true}},
+		{ID: "2", CloudInfo: client.CloudInfo{Name: "prod-db", Provider: "Azure", Environment: "Production", IsDraft: true}},
+		{ID: "3", CloudInfo: client.CloudInfo{Name: "dev", Provider: "AWS", Environment: "Development"}},
+	}
+
+	tests := []struct {
+		flags []string
+		ids   []string
+		err   string
+	}{
+		{flags: nil, ids: []string{"1", "2", "3"}},
+		{flags: []string{"--provider", "aws"}, ids: []string{"1", "3"}},
+		{flags: []string{"-e", "production", "--name", "PROD-*"}, ids: []string{"1", "2"}},
+		{flags: []string{"--tag", "pci", "--valid"}, ids: []string{"1"}},
+		{flags: []string{"--invalid"}, ids: []string{"2", "3"}},
+		{flags: []string{"--draft"}, ids: []string{"2"}},
+		{flags: []string{"--draft=false"}, ids: []string{"1", "3"}},
+		{flags: []string{"--name", "staging-*"}, ids: []string{}},
+		{flags: []string{"--provider", "gcp"}, err: `Provider must be one of AWS, Azure, got "gcp"`},
+		{flags: []string{"-e", "qa"}, err: `Environment must be one of Production, Staging, Development, Test, got "qa"`},
+		{flags: []string{"--name", "["}, err: `Invalid name pattern "["`},
+		{flags: []string{"--valid", "--invalid"}, err: "Use either --valid or --invalid"},
+	}
+
+	for _, tt := range tests {
+		frc := &fakeReleaseClient{cloudAccounts: clouds}
+		var buf bytes.Buffer
+		cmd := newCloudListCmd(frc, &buf)
+		assert.Nil(t, cmd.ParseFlags(tt.flags))
+		err := cmd.RunE(cmd, nil)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "%v", tt.flags)
+			continue
+		}
+		assert.Nil(t, err, "%v", tt.flags)
+
+		ids := []string{}
+		for _, cloud := range clouds {
+			if frc.cloudFilter.Match(cloud) {
+				ids = append(ids, cloud.ID)
+			}
+		}
+		assert.Equal(t, tt.ids, ids, "%v", tt.flags)
+	}
+
+	frc := &fakeReleaseClient{}
+	var buf bytes.Buffer
+	cmd := newCloudListCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--provider", "aws,AZURE", "--environment", "test"})
+	assert.Nil(t, cmd.RunE(cmd, nil), "an empty list is not an error")
+	assert.Equal(t, "No cloud accounts found.\n", buf.String())
+	assert.Equal(t, []string{"AWS", "Azure"}, frc.cloudFilter.Providers, "values are sent in the casing of the API")
+	assert.Equal(t, []string{"Test"}, frc.cloudFilter.Environments)
+}
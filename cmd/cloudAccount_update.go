@@ -9,7 +9,6 @@ import (
 
 	"github.com/CloudCoreo/cli/client"
 	"github.com/CloudCoreo/cli/cmd/util"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 
 	"github.com/CloudCoreo/cli/cmd/content"
 
@@ -19,10 +18,10 @@ import (
 )
 
 type cloudUpdateCmd struct {
-	out            io.Writer
-	client         command.Interface
-	cloud          command.CloudProvider
-	cloudID        string
+	out    io.Writer
+	client command.Interface
+	cloud  command.CloudProvider
+	cloudFlags
 	resourceName   string
 	roleName       string
 	externalID     string
@@ -48,14 +47,11 @@ func newCloudUpdateCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Short: content.CmdCloudUpdateShort,
 		Long:  content.CmdCloudUpdateLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := util.CheckCloudShowOrDeleteFlag(cloudUpdate.cloudID, verbose); err != nil {
-				return err
-			}
-
 			if cloudUpdate.client == nil {
-				cloudUpdate.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				cloudUpdate.client = newCoreoClient()
+			}
+			if err := cloudUpdate.resolveCloud(cloudUpdate.client); err != nil {
+				return err
 			}
 
 			if cloudUpdate.cloud == nil {
@@ -78,7 +74,7 @@ func newCloudUpdateCmd(client command.Interface, out io.Writer) *cobra.Command {
 	f.StringVarP(&cloudUpdate.email, content.CmdFlagEmail, "", "", content.CmdFlagEmailDescription)
 	f.StringVarP(&cloudUpdate.userName, content.CmdFlagUserName, "", "", content.CmdFlagUserNameDescription)
 	f.StringVarP(&cloudUpdate.environment, content.CmdFlagEnvironmentLong, content.CmdFlagEnvironmentShort, "", content.CmdFlagEnvironmentDescription)
-	f.StringVarP(&cloudUpdate.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	cloudUpdate.addFlags(f)
 	f.StringVarP(&cloudUpdate.awsProfile, content.CmdFlagAwsProfile, "", "", content.CmdFlagAwsProfileDescription)
 	f.StringVarP(&cloudUpdate.awsProfilePath, content.CmdFlagAwsProfilePath, "", "", content.CmdFlagAwsProfilePathDescription)
 	f.StringVarP(&cloudUpdate.policy, content.CmdFlagAwsPolicy, "", content.CmdFlagAwsPolicyDefault, content.CmdFlagAwsPolicyDescription)
@@ -106,25 +102,32 @@ func (t *cloudUpdateCmd) run() error {
 	}
 
 	if t.roleName != "" {
-		info, err := t.client.GetRoleCreationInfo(&input.CreateCloudAccountInput)
+		info, err := t.client.GetRoleCreationInfo(commandCtx, &input.CreateCloudAccountInput)
 		if err != nil {
 			return err
 		}
-		arn, externalID, err := t.cloud.CreateNewRole(info)
-		time.Sleep(10 * time.Second)
+		arn, externalID, err := t.cloud.CreateNewRole(commandCtx, info)
 		if err != nil {
 			return err
 		}
+		if err := sleepOrCancel(10 * time.Second); err != nil {
+			ctx, cancel := rollbackContext()
+			t.cloud.DeleteRole(ctx, t.roleName)
+			cancel()
+			return err
+		}
 
 		input.RoleArn = arn
 		input.ExternalID = externalID
 	}
 
-	cloud, err := t.client.UpdateCloudAccount(input)
+	cloud, err := t.client.UpdateCloudAccount(commandCtx, input)
 	if err != nil {
 		if t.roleName != "" {
 			fmt.Println("Cloud account update failed! Will delete created role.")
-			t.cloud.DeleteRole(t.roleName)
+			ctx, cancel := rollbackContext()
+			t.cloud.DeleteRole(ctx, t.roleName)
+			cancel()
 		}
 		return err
 	}
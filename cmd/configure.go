@@ -16,6 +16,7 @@ package main
 
 import (
 	"io"
+	"path/filepath"
 
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/pkg/command"
@@ -55,6 +56,32 @@ func newConfigureCmd(out io.Writer) *cobra.Command {
 
 func (t *configureCmd) run() error {
 
+	if clientID != "" {
+		t.configureOAuthApp()
+	} else {
+		t.configureAPIToken()
+	}
+
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.CSPURL), cspURL)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.Proxy), proxy)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.CABundle), caBundle)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.ClientCert), clientCert)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.ClientKey), clientKey)
+	if insecure {
+		util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.Insecure), "true")
+	}
+
+	// save config
+	if err := util.SaveViperConfig(); err != nil {
+		println("Unable to save config")
+	}
+	if !noCache {
+		fmt.Fprintf(t.out, content.InfoTokenCacheEnabled, filepath.Join(homePath(), content.TokenCacheFolder))
+	}
+	return nil
+}
+
+func (t *configureCmd) configureAPIToken() {
 	//generate config keys based on user profile
 	apiKey := fmt.Sprintf("%s.%s", userProfile, content.AccessKey)
 
@@ -68,12 +95,28 @@ func (t *configureCmd) run() error {
 
 	// replace values in config
 	util.UpdateConfig(apiKey, userAPIkey)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.AuthType), content.AuthTypeAPIToken)
+}
 
-	// save config
-	if err := util.SaveViperConfig(); err != nil {
-		println("Unable to save config")
+// configureOAuthApp makes the profile authenticate as the CSP OAuth app given by --client-id
+func (t *configureCmd) configureOAuthApp() {
+	secretKey := fmt.Sprintf("%s.%s", userProfile, content.ClientSecret)
+
+	userSecret := ""
+	current := clientSecret
+	if current == "" {
+		current = content.None
 	}
-	return nil
+	setValue(&userSecret, current, fmt.Sprintf(content.CmdConfigurePromptClientSecret, clientID, util.GetValueFromConfig(secretKey, true)))
+	if userSecret == "" {
+		// Keep the stored secret when the user just hits enter.
+		userSecret = util.CheckProfileFlag("", userProfile, content.ClientSecret)
+	}
+
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.AuthType), content.AuthTypeOAuthApp)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.ClientID), clientID)
+	util.UpdateConfig(secretKey, userSecret)
+	util.UpdateConfig(fmt.Sprintf("%s.%s", userProfile, content.OrgID), orgID)
 }
 
 func getValueFromUser(userKey *string, prompt string) {
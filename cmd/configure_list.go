@@ -28,7 +28,11 @@ import (
 // Profile struct for user
 type Profile struct {
 	ProfileName string
+	AuthType    string
 	APIKey      string
+	ClientID    string
+	CSPURL      string
+	Proxy       string
 	SecretKey   string
 	TeamID      string
 }
@@ -72,7 +76,11 @@ func (t *configureListCmd) run() error {
 
 		profile := &Profile{
 			ProfileName: k,
+			AuthType:    authType(k),
 			APIKey:      util.GetValueFromConfig(apiKey, true),
+			ClientID:    util.GetValueFromConfig(fmt.Sprintf("%s.%s", k, content.ClientID), false),
+			CSPURL:      util.GetValueFromConfig(fmt.Sprintf("%s.%s", k, content.CSPURL), false),
+			Proxy:       util.GetValueFromConfig(fmt.Sprintf("%s.%s", k, content.Proxy), false),
 		}
 
 		profiles = append(profiles, profile)
@@ -94,3 +102,11 @@ func (t *configureListCmd) run() error {
 	fmt.Println(table.Render())
 	return nil
 }
+
+// authType is the AUTH_TYPE of a profile, profiles saved before it existed use an API token
+func authType(profile string) string {
+	if t := util.CheckProfileFlag("", profile, content.AuthType); t != "" {
+		return t
+	}
+	return content.AuthTypeAPIToken
+}
@@ -0,0 +1,82 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdAPIUse api cmd
+	CmdAPIUse = "api METHOD PATH"
+
+	//CmdAPIShort short description
+	CmdAPIShort = "Make an authenticated request to any VMware Secure State API path"
+
+	//CmdAPILong long description
+	CmdAPILong = `Make an authenticated request to any VMware Secure State API path and print the
+response, pretty printed when it is JSON. The path is relative to the API endpoint of
+the profile, e.g. v1/cloudaccounts. See https://api.securestate.vmware.com for the
+available APIs.
+
+Fields given with -f are sent as query parameters of GET and DELETE requests and as
+a JSON object body otherwise, or as query parameters when --input gives the body.
+With --paginate, responses with a continuationToken are followed and their results
+are merged.`
+
+	//CmdAPIExample examples
+	CmdAPIExample = `  vss api GET v1/cloudaccounts
+  vss api GET v1/cloudaccounts/CLOUD_ID -H "Accept: application/json"
+  vss api POST v2/findings/query --input query.json --paginate
+  vss api POST v2/findings/query -f filters.levels=High --paginate`
+
+	//CmdFlagFieldLong field flag long
+	CmdFlagFieldLong = "field"
+
+	//CmdFlagFieldShort field flag short
+	CmdFlagFieldShort = "f"
+
+	//CmdFlagFieldDescription field flag description
+	CmdFlagFieldDescription = "Add a key=value field to the request, dots in the key nest JSON objects, can be repeated"
+
+	//CmdFlagHeaderLong header flag long
+	CmdFlagHeaderLong = "header"
+
+	//CmdFlagHeaderShort header flag short
+	CmdFlagHeaderShort = "H"
+
+	//CmdFlagHeaderDescription header flag description
+	CmdFlagHeaderDescription = "Add a \"Name: value\" request header, can be repeated"
+
+	//CmdFlagInputLong input flag long
+	CmdFlagInputLong = "input"
+
+	//CmdFlagInputDescription input flag description
+	CmdFlagInputDescription = "File to send as request body, - reads standard input"
+
+	//CmdFlagPaginateLong paginate flag long
+	CmdFlagPaginateLong = "paginate"
+
+	//CmdFlagPaginateDescription paginate flag description
+	CmdFlagPaginateDescription = "Fetch all pages by following continuationToken and merge their results"
+
+	//ErrorAPIArgs error
+	ErrorAPIArgs = "A method and a path are required, e.g. vss api GET v1/cloudaccounts"
+
+	//ErrorInvalidField error
+	ErrorInvalidField = "Invalid field %q, fields must look like key=value"
+
+	//ErrorInvalidHeader error
+	ErrorInvalidHeader = "Invalid header %q, headers must look like \"Name: value\""
+
+	//ErrorPaginateBody error
+	ErrorPaginateBody = "--paginate needs a JSON object request body to add the continuationToken to"
+)
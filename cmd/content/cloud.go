@@ -25,10 +25,56 @@ const (
 	CmdCloudLong = `Connect to your cloud accounts.`
 
 	//CmdCloudListShort short description
-	CmdCloudListShort = "Show list of tokens"
+	CmdCloudListShort = "Show list of cloud accounts"
 
 	//CmdCloudListLong long description
-	CmdCloudListLong = `Show list of tokens.`
+	CmdCloudListLong = `Show the cloud accounts of the team, all of them or those matching every
+filter flag given. A team without matching cloud accounts shows an empty list.`
+
+	//CmdFlagCloudListProviderDescription cloud list provider flag description
+	CmdFlagCloudListProviderDescription = "Only cloud accounts of these providers: AWS, Azure"
+
+	//CmdFlagCloudListEnvironmentDescription cloud list environment flag description
+	CmdFlagCloudListEnvironmentDescription = "Only cloud accounts of these environments: Production, Staging, Development, Test"
+
+	//CmdFlagTagLong tag flag long
+	CmdFlagTagLong = "tag"
+
+	//CmdFlagCloudListTagDescription cloud list tag flag description
+	CmdFlagCloudListTagDescription = "Only cloud accounts with all of these tags"
+
+	//CmdFlagCloudListNameDescription cloud list name flag description
+	CmdFlagCloudListNameDescription = "Only cloud accounts whose name matches this glob, e.g. 'prod-*', regardless of case"
+
+	//CmdFlagValidLong valid flag long
+	CmdFlagValidLong = "valid"
+
+	//CmdFlagValidDescription valid flag description
+	CmdFlagValidDescription = "Only cloud accounts whose role or key passed the last validation"
+
+	//CmdFlagInvalidLong invalid flag long
+	CmdFlagInvalidLong = "invalid"
+
+	//CmdFlagInvalidDescription invalid flag description
+	CmdFlagInvalidDescription = "Only cloud accounts whose role or key failed the last validation"
+
+	//CmdFlagDraftLong draft flag long
+	CmdFlagDraftLong = "draft"
+
+	//CmdFlagCloudListDraftDescription cloud list draft flag description
+	CmdFlagCloudListDraftDescription = "Only draft cloud accounts, --draft=false only the other ones"
+
+	//InfoNoCloudAccounts is printed when no cloud account matches the filters
+	InfoNoCloudAccounts = "No cloud accounts found."
+
+	//ErrorInvalidEnvironment error
+	ErrorInvalidEnvironment = "Environment must be one of Production, Staging, Development, Test, got %q"
+
+	//ErrorInvalidNameGlob error
+	ErrorInvalidNameGlob = "Invalid name pattern %q"
+
+	//ErrorValidAndInvalid error
+	ErrorValidAndInvalid = "Use either --valid or --invalid"
 
 	//CmdCloudTestShort short description
 	CmdCloudTestShort = "test role"
@@ -187,7 +233,22 @@ Add a cloud account. The result would be shown as the following if successful.
 	InfoUsingCloudAccount = "[ OK ] Using Cloud Account ID %s\n"
 
 	//ErrorCloudIDRequired error message
-	ErrorCloudIDRequired = "Cloud Account ID is required for this command. Use flag '--cloud-id'\n"
+	ErrorCloudIDRequired = "Cloud Account ID is required for this command. Use flag '--cloud-id' or '--cloud'\n"
+
+	//CmdFlagCloudLong cloud flag long
+	CmdFlagCloudLong = "cloud"
+
+	//CmdFlagCloudDescription cloud flag description
+	CmdFlagCloudDescription = "Name, AWS account ID, Azure subscription ID or Secure State ID of the cloud account, instead of --cloud-id"
+
+	//ErrorCloudIDAndCloud error
+	ErrorCloudIDAndCloud = "Use either --cloud-id or --cloud"
+
+	//ErrorCloudNotFound error
+	ErrorCloudNotFound = "No cloud account has the name, account ID, subscription ID or ID %q"
+
+	//ErrorCloudAmbiguous error
+	ErrorCloudAmbiguous = "%q matches %d cloud accounts, use --cloud-id with one of them:\n  %s"
 
 	//CmdFlagDeleteRole is a flag to delete the role while deleting a cloud account
 	CmdFlagDeleteRole = "role"
@@ -222,4 +283,103 @@ Add a cloud account. The result would be shown as the following if successful.
 	CmdFlagTags = "tags"
 
 	CmdFlagTagsDescription = "Set tags for account"
+
+	//CmdCloudImportUse cloud import cmd
+	CmdCloudImportUse = "import FILE"
+
+	//CmdCloudImportShort short description
+	CmdCloudImportShort = "Add the cloud accounts of a manifest"
+
+	//CmdCloudImportLong long description
+	CmdCloudImportLong = `Add the cloud accounts listed in a YAML or CSV manifest, skipping those the
+team already has an account of the same name for, so an import can be run
+again after fixing failed rows. AWS rows with roleName get a new role created
+through their awsProfile, as with cloud add --role. With --event-setup the event
+stream of every row is set up too. Prints the outcome of each row and fails
+when any row failed.
+
+YAML manifests list cloudAccounts, CSV manifests have a header row with the
+same keys and separate tags with |:
+
+  cloudAccounts:
+  - name: prod
+    provider: AWS
+    environment: Production
+    accountId: "123456789012"
+    awsProfile: prod
+    roleName: securestate_role
+  - name: azure-prod
+    provider: Azure
+    environment: Production
+    subscriptionId: SUBSCRIPTION_ID
+    applicationId: APPLICATION_ID
+    directoryId: DIRECTORY_ID
+    keyValue: ${AZURE_PROD_KEY}
+
+keyValue and externalId may reference environment variables as ${NAME}.`
+
+	//CmdCloudImportExample examples
+	CmdCloudImportExample = `  vss cloud import accounts.yaml --event-setup --ignore-missing-trails
+  vss cloud import accounts.csv --report import-report.csv`
+
+	//CmdCloudExportUse cloud export cmd
+	CmdCloudExportUse = "export [FILE]"
+
+	//CmdCloudExportShort short description
+	CmdCloudExportShort = "Write the cloud accounts of the team to a YAML or CSV manifest"
+
+	//CmdCloudExportLong long description
+	CmdCloudExportLong = `Write every cloud account of the team to a manifest that cloud import and
+apply take back, to keep a backup or add the same accounts to another team.
+The format follows the extension of FILE, without FILE the manifest is printed
+in --format.
+
+Secrets are not exported: keyValue of Azure accounts and externalId of AWS
+accounts are replaced by ${VSS_NAME_KEY_VALUE} and ${VSS_NAME_EXTERNAL_ID}
+placeholders, set these environment variables before importing the manifest.
+Fields set by the server, such as the cloud account ID and the validation
+status, are left out.`
+
+	//CmdCloudExportExample examples
+	CmdCloudExportExample = `  vss cloud export accounts.yaml
+  vss cloud export --format csv > accounts.csv`
+
+	//CmdFlagCloudExportFormatDescription cloud export format flag description
+	CmdFlagCloudExportFormatDescription = "Format of the manifest printed without FILE: yaml or csv"
+
+	//InfoCloudExported is printed once the manifest is written
+	InfoCloudExported = "Exported %d cloud accounts to %s\n"
+
+	//InfoCloudExportPlaceholders lists the environment variables to set before importing
+	InfoCloudExportPlaceholders = "Set these environment variables to the secrets of the accounts before importing it:\n  %s\n"
+
+	//ErrorInvalidManifestFormat error
+	ErrorInvalidManifestFormat = "Invalid format %s, use yaml or csv"
+
+	//CmdFlagEventSetupLong event setup flag long
+	CmdFlagEventSetupLong = "event-setup"
+
+	//CmdFlagEventSetupDescription event setup flag description
+	CmdFlagEventSetupDescription = "Set up the event stream of every row, including accounts that already existed"
+
+	//CmdFlagReportLong report flag long
+	CmdFlagReportLong = "report"
+
+	//CmdFlagImportReportDescription import report flag description
+	CmdFlagImportReportDescription = "Also write the outcome of each row to this file, as JSON when it ends in .json and as CSV otherwise"
+
+	//InfoImportingCloudAccount is printed before each row is imported
+	InfoImportingCloudAccount = "Importing %s (%d/%d)...\n"
+
+	//ErrorCloudAccountNameRequired error
+	ErrorCloudAccountNameRequired = "Cloud account name is required"
+
+	//ErrorDuplicateCloudAccountName error
+	ErrorDuplicateCloudAccountName = "Name already used on row %d"
+
+	//ErrorRoleInOtherAccount error
+	ErrorRoleInOtherAccount = "Created role %s is not in account %s, check the AWS profile. The role was deleted"
+
+	//ErrorImportFailed error
+	ErrorImportFailed = "%d of %d rows failed"
 )
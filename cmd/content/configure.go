@@ -33,16 +33,28 @@ Note:  the  values  you  provide  for the VMware Secure State Access Key ID and
 Secret Access Key will  be  written  to  the  shared  credentials  file
 ($HOME/.vss/profiles.yaml).
 
+The access tokens CSP exchanges them for are also written to disk by default,
+under $HOME/.vss/tokens, and reused until shortly before they expire. Pass
+--no-token-cache to any command to keep access tokens off disk.
+
 `
 
+	//InfoTokenCacheEnabled is printed after configure saves a profile
+	InfoTokenCacheEnabled = "Access tokens of this profile will be cached in %s until they expire, use --no-token-cache to keep them off disk\n"
+
 	//CmdConfigureExample is examples for vss configure command
 	CmdConfigureExample = `  vss configure
   vss configure --api-key VSS_API_KEY --api-secret VSS_API_SECRET --team-id VSS_TEAM_ID
+  vss configure --profile staging --csp-url https://console-stg.cloud.vmware.com
+  vss configure --profile ci --client-id OAUTH_APP_ID --client-secret OAUTH_APP_SECRET --org-id ORG_ID
   vss configure list`
 
 	//CmdConfigurePromptAPIKEY prompt for api key
 	CmdConfigurePromptAPIKEY = "Enter your VMware Secure State api token key (available on https://app.cloudcoreo.com under Settings -> API Tokens) [%s]: "
 
+	//CmdConfigurePromptClientSecret prompt for oauth app client secret
+	CmdConfigurePromptClientSecret = "Enter the client secret of the CSP OAuth app %s [%s]: "
+
 	//CmdConfigurePromptSecretKEY prompt for secret key
 	CmdConfigurePromptSecretKEY = "Enter your VMware Secure State api token secret key (available on https://app.cloudcoreo.com under Settings -> API Tokens) [%s]: "
 
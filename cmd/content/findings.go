@@ -0,0 +1,439 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdFindingsUse findings cmd
+	CmdFindingsUse = "findings"
+
+	//CmdFindingsShort short description
+	CmdFindingsShort = "Query the findings of your cloud accounts"
+
+	//CmdFindingsLong long description
+	CmdFindingsLong = `Query the findings, the rule violations, found on the objects of your cloud accounts.`
+
+	//CmdFindingsListShort short description
+	CmdFindingsListShort = "List findings"
+
+	//CmdFindingsListLong long description
+	CmdFindingsListLong = `List the findings matching all of the given filters. Each filter flag takes a
+comma separated list of values, or can be repeated, and matches any of them.
+All pages of results are fetched unless --limit is given.`
+
+	//CmdFindingsListExample examples
+	CmdFindingsListExample = `  vss findings list --severity high --status open
+  vss findings list --cloud-id CLOUD_ID --rule-id RULE_ID --region us-east-1,us-west-2
+  vss findings list --provider Azure --limit 50 --json`
+
+	//CmdFindingsExportUse findings export cmd
+	CmdFindingsExportUse = "export"
+
+	//CmdFindingsExportShort short description
+	CmdFindingsExportShort = "Export findings for other security tools"
+
+	//CmdFindingsExportLong long description
+	CmdFindingsExportLong = `Export the findings matching all of the given filters as a SARIF 2.1.0 log.
+Each Secure State rule becomes a SARIF rule and each violating object a result
+located at provider/cloud account/region/object, with High, Medium and Low
+severities as the error, warning and note levels. Rules and results are sorted
+so exporting the same findings twice gives the same file.`
+
+	//CmdFindingsExportExample examples
+	CmdFindingsExportExample = `  vss findings export --format sarif --output findings.sarif
+  vss findings export --cloud-id CLOUD_ID --status open > findings.sarif`
+
+	//CmdFlagFormatLong format flag long
+	CmdFlagFormatLong = "format"
+
+	//CmdFlagExportFormatDescription export format flag description
+	CmdFlagExportFormatDescription = "Format of the export, only sarif is supported"
+
+	//CmdFlagOutputLong output flag long
+	CmdFlagOutputLong = "output"
+
+	//CmdFlagOutputShort output flag short
+	CmdFlagOutputShort = "o"
+
+	//CmdFlagOutputDescription output flag description
+	CmdFlagOutputDescription = "File to write to instead of standard output"
+
+	//CmdFindingsGateUse findings gate cmd
+	CmdFindingsGateUse = "gate"
+
+	//CmdFindingsGateShort short description
+	CmdFindingsGateShort = "Fail when there are new findings that are not in the baseline"
+
+	//CmdFindingsGateLong long description
+	CmdFindingsGateLong = `Compare the open findings matching the filters with a baseline file created by
+'vss findings baseline create' and print how many are new, fixed and accepted.
+The command exits with code 2 when new findings are at or above the --fail-on
+severity, with 0 when there are none, and with any other code when the gate
+could not be evaluated, e.g. because the baseline is missing. New findings with
+a severity other than Low, Medium or High always fail the gate.
+
+Findings are matched on their rule, cloud account, region and object only, so
+a finding is still accepted after its status, severity or timestamps change.`
+
+	//CmdFindingsGateExample examples
+	CmdFindingsGateExample = `  vss findings gate --baseline vss-baseline.json
+  vss findings gate --cloud-id CLOUD_ID --fail-on medium`
+
+	//CmdFindingsBaselineUse findings baseline cmd
+	CmdFindingsBaselineUse = "baseline"
+
+	//CmdFindingsBaselineShort short description
+	CmdFindingsBaselineShort = "Manage the baseline of accepted findings"
+
+	//CmdFindingsBaselineLong long description
+	CmdFindingsBaselineLong = `Manage the baseline file of accepted findings that 'vss findings gate' compares
+with. Commit it next to your pipeline and use the same filters for both commands.`
+
+	//CmdBaselineCreateUse baseline create cmd
+	CmdBaselineCreateUse = "create"
+
+	//CmdBaselineCreateShort short description
+	CmdBaselineCreateShort = "Accept the current open findings in a new baseline"
+
+	//CmdBaselineCreateLong long description
+	CmdBaselineCreateLong = `Write a baseline file accepting all open findings matching the filters.`
+
+	//CmdBaselineCreateExample examples
+	CmdBaselineCreateExample = `  vss findings baseline create
+  vss findings baseline create --cloud-id CLOUD_ID --baseline prod-baseline.json`
+
+	//CmdBaselineUpdateUse baseline update cmd
+	CmdBaselineUpdateUse = "update"
+
+	//CmdBaselineUpdateShort short description
+	CmdBaselineUpdateShort = "Accept new findings and drop fixed ones from a baseline"
+
+	//CmdBaselineUpdateLong long description
+	CmdBaselineUpdateLong = `Rewrite an existing baseline file so that it accepts exactly the open findings
+matching the filters, and print how many findings were added and removed.
+Accepted findings outside the filters are kept as they are.`
+
+	//CmdBaselineUpdateExample examples
+	CmdBaselineUpdateExample = `  vss findings baseline update
+  vss findings baseline update --cloud-id CLOUD_ID --baseline prod-baseline.json`
+
+	//CmdFlagBaselineLong baseline flag long
+	CmdFlagBaselineLong = "baseline"
+
+	//CmdFlagBaselineDescription baseline flag description
+	CmdFlagBaselineDescription = "Baseline file of accepted findings"
+
+	//CmdFlagForceLong force flag long
+	CmdFlagForceLong = "force"
+
+	//CmdFlagBaselineForceDescription baseline force flag description
+	CmdFlagBaselineForceDescription = "Overwrite the baseline file if it exists"
+
+	//CmdFlagFailOnLong fail on flag long
+	CmdFlagFailOnLong = "fail-on"
+
+	//CmdFlagFailOnDescription fail on flag description
+	CmdFlagFailOnDescription = "Lowest severity of new findings that fails the gate: High, Medium, Low"
+
+	//CmdFindingsSnapshotUse findings snapshot cmd
+	CmdFindingsSnapshotUse = "snapshot"
+
+	//CmdFindingsSnapshotShort short description
+	CmdFindingsSnapshotShort = "Save the current findings to a file"
+
+	//CmdFindingsSnapshotLong long description
+	CmdFindingsSnapshotLong = `Save the findings matching the filters to a snapshot file, to compare them
+later with 'vss findings diff'.`
+
+	//CmdFindingsSnapshotExample examples
+	CmdFindingsSnapshotExample = `  vss findings snapshot --output before-sprint.json
+  vss findings snapshot --cloud-id CLOUD_ID`
+
+	//CmdFlagSnapshotOutputDescription snapshot output flag description
+	CmdFlagSnapshotOutputDescription = "Snapshot file to write, default vss-findings-TIMESTAMP.json"
+
+	//DefaultSnapshotFile is the snapshot file name when --output is not given
+	DefaultSnapshotFile = "vss-findings-%s.json"
+
+	//CmdFindingsDiffUse findings diff cmd
+	CmdFindingsDiffUse = "diff FROM TO"
+
+	//CmdFindingsDiffShort short description
+	CmdFindingsDiffShort = "Compare findings between two snapshots or points in time"
+
+	//CmdFindingsDiffLong long description
+	CmdFindingsDiffLong = `Report which open findings are new, resolved and still open in TO compared with
+FROM, grouped by cloud account and rule. FROM and TO are each a snapshot file
+saved by 'vss findings snapshot', a time as YYYY-MM-DD or RFC 3339, or 'now'.
+The filter flags apply to both sides, a snapshot is narrowed down further to
+the findings matching them.`
+
+	//CmdFindingsDiffExample examples
+	CmdFindingsDiffExample = `  vss findings diff before-sprint.json now
+  vss findings diff 2026-09-01 2026-10-01 --cloud-id CLOUD_ID --format markdown`
+
+	//CmdFlagDiffFormatDescription diff format flag description
+	CmdFlagDiffFormatDescription = "Output format: table, json or markdown"
+
+	//CmdFindingsForwardUse findings forward cmd
+	CmdFindingsForwardUse = "forward"
+
+	//CmdFindingsForwardShort short description
+	CmdFindingsForwardShort = "Forward finding changes to a SIEM"
+
+	//CmdFindingsForwardLong long description
+	CmdFindingsForwardLong = `Send the findings matching the filters to Splunk HTTP Event Collector, an RFC 5424
+syslog receiver over TCP, TLS or UDP, or a JSON webhook. A checkpoint under
+$VSS_HOME/forward, one per destination and filters, remembers what was sent, so
+that each run only sends new findings, findings whose status or severity
+changed, and findings that are gone as resolved. The checkpoint only moves once
+every event was delivered.
+
+HTTP targets use the proxy and TLS settings of the profile, syslog over TLS its
+CA bundle, client certificate and insecure setting.`
+
+	//CmdFindingsForwardExample examples
+	CmdFindingsForwardExample = `  VSS_HEC_TOKEN=TOKEN vss findings forward --target splunk --url https://splunk.example.com:8088
+  vss findings forward --target syslog --address siem.example.com:6514 --protocol tls --severity high
+  vss findings forward --target webhook --url https://hooks.example.com/vss -H "X-Api-Key: KEY"`
+
+	//CmdFlagTargetLong target flag long
+	CmdFlagTargetLong = "target"
+
+	//CmdFlagTargetDescription target flag description
+	CmdFlagTargetDescription = "Where to forward to: splunk, syslog or webhook"
+
+	//CmdFlagURLLong url flag long
+	CmdFlagURLLong = "url"
+
+	//CmdFlagForwardURLDescription forward url flag description
+	CmdFlagForwardURLDescription = "URL of the Splunk HTTP Event Collector or of the webhook"
+
+	//CmdFlagHECTokenLong hec token flag long
+	CmdFlagHECTokenLong = "token"
+
+	//CmdFlagHECTokenDescription hec token flag description
+	CmdFlagHECTokenDescription = "Splunk HTTP Event Collector token, default $VSS_HEC_TOKEN"
+
+	//CmdFlagAddressLong address flag long
+	CmdFlagAddressLong = "address"
+
+	//CmdFlagSyslogAddressDescription syslog address flag description
+	CmdFlagSyslogAddressDescription = "host:port of the syslog receiver"
+
+	//CmdFlagProtocolLong protocol flag long
+	CmdFlagProtocolLong = "protocol"
+
+	//CmdFlagSyslogProtocolDescription syslog protocol flag description
+	CmdFlagSyslogProtocolDescription = "Syslog transport: tcp, tls or udp"
+
+	//CmdFlagWebhookHeaderDescription webhook header flag description
+	CmdFlagWebhookHeaderDescription = "Add a \"Name: value\" header to webhook requests, can be repeated"
+
+	//CmdFlagBatchSizeLong batch size flag long
+	CmdFlagBatchSizeLong = "batch-size"
+
+	//CmdFlagBatchSizeDescription batch size flag description
+	CmdFlagBatchSizeDescription = "Most events sent per HTTP request"
+
+	//CmdFlagCheckpointLong checkpoint flag long
+	CmdFlagCheckpointLong = "checkpoint"
+
+	//CmdFlagCheckpointDescription checkpoint flag description
+	CmdFlagCheckpointDescription = "Checkpoint file, by default one per profile, destination and filters under $VSS_HOME/forward"
+
+	//CmdFlagFullLong full flag long
+	CmdFlagFullLong = "full"
+
+	//CmdFlagFullDescription full flag description
+	CmdFlagFullDescription = "Ignore the checkpoint and send every finding as new"
+
+	//ForwardCheckpointFolder folder under the config home for forward checkpoints
+	ForwardCheckpointFolder = "forward"
+
+	//CmdFindingsSyncJiraUse findings sync-jira cmd
+	CmdFindingsSyncJiraUse = "sync-jira"
+
+	//CmdFindingsSyncJiraShort short description
+	CmdFindingsSyncJiraShort = "Keep Jira issues in step with findings"
+
+	//CmdFindingsSyncJiraLong long description
+	CmdFindingsSyncJiraLong = `Create a Jira issue for each open finding matching the filters that has none,
+comment on the closed issue of a finding that reoccurs, once unless
+reopenTransition reopens it, and close the issue of a finding that was resolved. Issues are found by the finding ID they store in a
+custom field, so running the sync again changes nothing.
+
+The mapping file tells where issues go:
+
+  url: https://example.atlassian.net
+  project: SEC
+  issueType: Bug
+  component: Cloud Security
+  labels: [vss]
+  findingIdField: customfield_10042
+  resolveTransition: Done
+  reopenTransition: Reopen
+  severities:
+    High: {priority: Highest}
+    Medium: {priority: Medium}
+    Low: {project: SECLOW, priority: Low}
+
+Jira Cloud needs the account e-mail and an API token, Jira Data Center a
+personal access token only.`
+
+	//CmdFindingsSyncJiraExample examples
+	CmdFindingsSyncJiraExample = `  vss findings sync-jira --mapping vss-jira.yaml --dry-run
+  VSS_JIRA_USER=me@example.com VSS_JIRA_TOKEN=TOKEN vss findings sync-jira --severity high,medium`
+
+	//CmdFlagMappingLong mapping flag long
+	CmdFlagMappingLong = "mapping"
+
+	//CmdFlagMappingDescription mapping flag description
+	CmdFlagMappingDescription = "Mapping file telling which Jira project, component and priority issues get"
+
+	//CmdFlagJiraUserLong jira user flag long
+	CmdFlagJiraUserLong = "jira-user"
+
+	//CmdFlagJiraUserDescription jira user flag description
+	CmdFlagJiraUserDescription = "Jira account e-mail, default $VSS_JIRA_USER. Leave empty to use a personal access token"
+
+	//CmdFlagJiraTokenLong jira token flag long
+	CmdFlagJiraTokenLong = "jira-token"
+
+	//CmdFlagJiraTokenDescription jira token flag description
+	CmdFlagJiraTokenDescription = "Jira API token or personal access token, default $VSS_JIRA_TOKEN"
+
+	//CmdFlagDryRunLong dry run flag long
+	CmdFlagDryRunLong = "dry-run"
+
+	//CmdFlagJiraDryRunDescription jira dry run flag description
+	CmdFlagJiraDryRunDescription = "Print the planned actions without changing Jira"
+
+	//DefaultJiraMappingFile is the mapping file when --mapping is not given
+	DefaultJiraMappingFile = "vss-jira.yaml"
+
+	//CmdFlagFindingCloudIDLong cloud id filter flag long
+	CmdFlagFindingCloudIDLong = "cloud-id"
+
+	//CmdFlagFindingCloudIDDescription cloud id filter flag description
+	CmdFlagFindingCloudIDDescription = "Only findings of these Secure State cloud account IDs"
+
+	//CmdFlagRuleIDLong rule id flag long
+	CmdFlagRuleIDLong = "rule-id"
+
+	//CmdFlagRuleIDDescription rule id flag description
+	CmdFlagRuleIDDescription = "Only findings of these rule IDs"
+
+	//CmdFlagSeverityLong severity flag long
+	CmdFlagSeverityLong = "severity"
+
+	//CmdFlagSeverityDescription severity flag description
+	CmdFlagSeverityDescription = "Only findings of these severities: High, Medium, Low"
+
+	//CmdFlagFindingProviderLong provider filter flag long
+	CmdFlagFindingProviderLong = "provider"
+
+	//CmdFlagFindingProviderDescription provider filter flag description
+	CmdFlagFindingProviderDescription = "Only findings of these cloud providers: AWS, Azure"
+
+	//CmdFlagRegionLong region flag long
+	CmdFlagRegionLong = "region"
+
+	//CmdFlagRegionDescription region flag description
+	CmdFlagRegionDescription = "Only findings in these regions"
+
+	//CmdFlagStatusLong status flag long
+	CmdFlagStatusLong = "status"
+
+	//CmdFlagStatusDescription status flag description
+	CmdFlagStatusDescription = "Only findings with these statuses: Open, Resolved, Suppressed"
+
+	//CmdFlagLimitLong limit flag long
+	CmdFlagLimitLong = "limit"
+
+	//CmdFlagLimitDescription limit flag description
+	CmdFlagLimitDescription = "Return at most this many findings, 0 returns all of them"
+
+	//ErrorInvalidSeverity error
+	ErrorInvalidSeverity = "Severity must be one of High, Medium, Low, got %q"
+
+	//ErrorInvalidFindingStatus error
+	ErrorInvalidFindingStatus = "Status must be one of Open, Resolved, Suppressed, got %q"
+
+	//ErrorInvalidFindingProvider error
+	ErrorInvalidFindingProvider = "Provider must be one of AWS, Azure, got %q"
+
+	//ErrorInvalidLimit error
+	ErrorInvalidLimit = "--limit cannot be negative"
+
+	//ErrorInvalidExportFormat error
+	ErrorInvalidExportFormat = "Unsupported export format %q, only sarif is supported"
+
+	//InfoFindingsExported is printed after the export was written to a file
+	InfoFindingsExported = "Exported %d findings to %s\n"
+
+	//ErrorBaselineExists error
+	ErrorBaselineExists = "Baseline %s already exists, use 'vss findings baseline update' or --force"
+
+	//ErrorGateFailed error
+	ErrorGateFailed = "%d new findings at or above %s severity"
+
+	//InfoBaselineCreated is printed after a baseline was written
+	InfoBaselineCreated = "Created baseline %s accepting %d findings\n"
+
+	//InfoBaselineUpdated is printed after a baseline was rewritten
+	InfoBaselineUpdated = "Updated baseline %s: %d new findings accepted, %d fixed findings removed, %d findings accepted in total\n"
+
+	//InfoGateSummary is the summary of findings gate
+	InfoGateSummary = "New:      %d (%d at or above %s)\nFixed:    %d\nAccepted: %d\n"
+
+	//ErrorInvalidDiffFormat error
+	ErrorInvalidDiffFormat = "Unsupported format %q, use table, json or markdown"
+
+	//ErrorInvalidDiffSide error
+	ErrorInvalidDiffSide = "%q is neither a snapshot file, a time as YYYY-MM-DD or RFC 3339, nor 'now'"
+
+	//InfoSnapshotSaved is printed after a snapshot was written
+	InfoSnapshotSaved = "Saved %d findings to %s\n"
+
+	//InfoDiffSummary is the summary of findings diff
+	InfoDiffSummary = "New:        %d\nResolved:   %d\nStill open: %d\n"
+
+	//ErrorInvalidForwardTarget error
+	ErrorInvalidForwardTarget = "Unsupported target %q, use '--target' splunk, syslog or webhook"
+
+	//ErrorSplunkFlagsRequired error
+	ErrorSplunkFlagsRequired = "Forwarding to Splunk needs the collector URL and an HEC token. Use flags '--url' and '--token'\n"
+
+	//ErrorWebhookURLRequired error
+	ErrorWebhookURLRequired = "Forwarding to a webhook needs its URL. Use flag '--url'\n"
+
+	//ErrorSyslogAddressRequired error
+	ErrorSyslogAddressRequired = "Forwarding to syslog needs the receiver address. Use flag '--address'\n"
+
+	//InfoNothingToForward is printed when no finding changed since the last forward
+	InfoNothingToForward = "No finding changed since the last forward."
+
+	//InfoFindingsForwarded is printed after events were forwarded
+	InfoFindingsForwarded = "Forwarded %d events (%d new, %d changed, %d resolved) to %s\n"
+
+	//InfoJiraInSync is printed when Jira needs no change
+	InfoJiraInSync = "Jira is in step with the findings, nothing to do."
+
+	//InfoJiraDryRun is printed before the planned actions of a dry run
+	InfoJiraDryRun = "Dry run, Jira was not changed. Planned actions:"
+
+	//InfoNoFindings is printed when no finding matches the filters
+	InfoNoFindings = "No findings found."
+)
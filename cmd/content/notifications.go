@@ -0,0 +1,146 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package content
+
+const (
+	//CmdNotificationsUse notifications cmd
+	CmdNotificationsUse = "notifications"
+
+	//CmdNotificationsShort short description
+	CmdNotificationsShort = "Manage notification targets"
+
+	//CmdNotificationsLong long description
+	CmdNotificationsLong = `Manage notification targets, the webhooks, Slack channels and e-mail
+recipients told about new findings. A target can be narrowed to severities and
+cloud accounts.`
+
+	//CmdNotificationsListShort short description
+	CmdNotificationsListShort = "List notification targets"
+
+	//CmdNotificationsListLong long description
+	CmdNotificationsListLong = `List all notification targets. Tables only show the host of URLs, since
+the path of Slack and many webhook URLs is a secret; --json shows them whole.`
+
+	//CmdNotificationsCreateShort short description
+	CmdNotificationsCreateShort = "Create a notification target"
+
+	//CmdNotificationsCreateLong long description
+	CmdNotificationsCreateLong = `Create a notification target. Webhook and Slack targets need --url, e-mail
+targets --email. Without --severity and --cloud-id the target is told about
+every new finding.`
+
+	//CmdNotificationsCreateExample examples
+	CmdNotificationsCreateExample = `  vss notifications create --name security --type slack --url https://hooks.slack.com/services/T/B/X --severity high
+  vss notifications create --name oncall --type email --email oncall@example.com,secops@example.com --cloud-id CLOUD_ID`
+
+	//CmdNotificationsUpdateShort short description
+	CmdNotificationsUpdateShort = "Update a notification target"
+
+	//CmdNotificationsUpdateLong long description
+	CmdNotificationsUpdateLong = `Update a notification target. Only the settings whose flags are given change.`
+
+	//CmdNotificationsUpdateExample examples
+	CmdNotificationsUpdateExample = `  vss notifications update --notification-id NOTIFICATION_ID --severity high,medium
+  vss notifications update --notification-id NOTIFICATION_ID --enabled=false`
+
+	//CmdNotificationsDeleteShort short description
+	CmdNotificationsDeleteShort = "Delete a notification target"
+
+	//CmdNotificationsDeleteLong long description
+	CmdNotificationsDeleteLong = `Delete a notification target, it is told about no more findings.`
+
+	//CmdNotificationsTestShort short description
+	CmdNotificationsTestShort = "Send a sample notification"
+
+	//CmdNotificationsTestLong long description
+	CmdNotificationsTestLong = `Send a sample notification to a target and report whether it was delivered.
+Fails when it was not, with the status the target answered.`
+
+	//CmdFlagNotificationIDLong notification id flag long
+	CmdFlagNotificationIDLong = "notification-id"
+
+	//CmdFlagNotificationIDDescription notification id flag description
+	CmdFlagNotificationIDDescription = "Notification target ID"
+
+	//CmdFlagNotificationNameDescription notification name flag description
+	CmdFlagNotificationNameDescription = "Name of the notification target"
+
+	//CmdFlagNotificationTypeLong notification type flag long
+	CmdFlagNotificationTypeLong = "type"
+
+	//CmdFlagNotificationTypeDescription notification type flag description
+	CmdFlagNotificationTypeDescription = "Type of the notification target: webhook, slack or email"
+
+	//CmdFlagNotificationURLDescription notification url flag description
+	CmdFlagNotificationURLDescription = "URL of the webhook or Slack incoming webhook"
+
+	//CmdFlagNotificationEmailDescription notification email flag description
+	CmdFlagNotificationEmailDescription = "E-mail addresses notified"
+
+	//CmdFlagNotificationSeverityDescription notification severity flag description
+	CmdFlagNotificationSeverityDescription = "Only notify about findings of these severities: High, Medium, Low"
+
+	//CmdFlagNotificationCloudIDDescription notification cloud id flag description
+	CmdFlagNotificationCloudIDDescription = "Only notify about findings of these Secure State cloud account IDs"
+
+	//CmdFlagEnabledLong enabled flag long
+	CmdFlagEnabledLong = "enabled"
+
+	//CmdFlagEnabledDescription enabled flag description
+	CmdFlagEnabledDescription = "Whether the target is notified, --enabled=false pauses it"
+
+	//ErrorNotificationIDRequired error
+	ErrorNotificationIDRequired = "Notification target ID is required for this command. Use flag '--notification-id'\n"
+
+	//ErrorNotificationNameRequired error
+	ErrorNotificationNameRequired = "Notification target name is required. Use flag '--name'\n"
+
+	//ErrorNotificationTypeRequired error
+	ErrorNotificationTypeRequired = "Notification target type is required. Use flag '--type' webhook, slack or email\n"
+
+	//ErrorInvalidNotificationType error
+	ErrorInvalidNotificationType = "Invalid type %q, use webhook, slack or email"
+
+	//ErrorNotificationURLRequired error
+	ErrorNotificationURLRequired = "%s targets need an http or https URL. Use flag '--url'\n"
+
+	//ErrorNotificationEmailRequired error
+	ErrorNotificationEmailRequired = "Email targets need at least one address. Use flag '--email'\n"
+
+	//ErrorNotificationNotDelivered error
+	ErrorNotificationNotDelivered = "Sample notification to %s was not delivered: %s"
+
+	//InfoUsingNotificationID info
+	InfoUsingNotificationID = "[ OK ] Using Notification target ID %s\n"
+
+	//InfoNotificationDeleted info
+	InfoNotificationDeleted = "Notification target deleted successfully!"
+
+	//InfoNotificationDelivered info
+	InfoNotificationDelivered = "Sample notification to %s was delivered.\n"
+
+	//InfoNoDeliveryStatus is reported when a target gave no reason for a failed delivery
+	InfoNoDeliveryStatus = "no status reported"
+
+	//InfoNoNotifications is printed when there are no notification targets
+	InfoNoNotifications = "No notification targets found."
+
+	//InfoAllSeverities is shown for targets notified about every severity
+	InfoAllSeverities = "All"
+
+	//InfoAllCloudAccounts is shown for targets notified about every cloud account
+	InfoAllCloudAccounts = "All"
+)
@@ -0,0 +1,83 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdPlanUse plan cmd
+	CmdPlanUse = "plan FILE"
+
+	//CmdPlanShort short description
+	CmdPlanShort = "Show the changes that apply would make to the cloud accounts"
+
+	//CmdPlanLong long description
+	CmdPlanLong = `Compare a YAML or CSV manifest of cloud accounts, in the format of cloud
+import, with the cloud accounts of the team and show what 'vss apply' would do:
+
+  + create  accounts in the manifest the team has no account of that name for
+  ~ update  accounts whose environment, draft flag, tags, role ARN or scan
+            settings differ, with the old and new value of each field
+  - delete  accounts of the team missing from the manifest, only deleted by
+            'vss apply --prune'
+
+Accounts are matched by name. Tags, roleArn, scanEnabled, scanInterval and
+scanRegion left out of the manifest are left as they are. Nothing is changed.`
+
+	//CmdPlanExample examples
+	CmdPlanExample = `  vss plan cloud-accounts.yaml
+  vss plan cloud-accounts.yaml --json`
+
+	//CmdApplyUse apply cmd
+	CmdApplyUse = "apply FILE"
+
+	//CmdApplyShort short description
+	CmdApplyShort = "Make the cloud accounts of the team match a manifest"
+
+	//CmdApplyLong long description
+	CmdApplyLong = `Show the plan of 'vss plan' for a manifest of cloud accounts and make its
+changes: accounts are created like with cloud import, updated like with cloud
+update and, with --prune only, deleted. Stops at the first change that fails,
+running apply again picks up the remaining changes.`
+
+	//CmdApplyExample examples
+	CmdApplyExample = `  vss apply cloud-accounts.yaml
+  vss apply cloud-accounts.yaml --prune`
+
+	//CmdFlagPruneLong prune flag long
+	CmdFlagPruneLong = "prune"
+
+	//CmdFlagPruneDescription prune flag description
+	CmdFlagPruneDescription = "Delete the cloud accounts of the team missing from the manifest"
+
+	//InfoPlanNoChanges is printed when the team matches the manifest
+	InfoPlanNoChanges = "No changes, the cloud accounts match %s.\n"
+
+	//InfoPlanDeleteSkipped is appended to deletes made only with --prune
+	InfoPlanDeleteSkipped = " (kept, apply with --prune to delete)"
+
+	//InfoPlanSummary is printed after the changes of a plan
+	InfoPlanSummary = "Plan: %d to create, %d to update, %d to delete.\n"
+
+	//InfoApplied is printed after each change is made
+	InfoApplied = "%s %s (%s): done\n"
+
+	//InfoApplyComplete is printed once every change is made
+	InfoApplyComplete = "Apply complete: %d created, %d updated, %d deleted.\n"
+
+	//ErrorDuplicateExistingCloudAccountName error
+	ErrorDuplicateExistingCloudAccountName = "Cloud accounts %s and %s of the team are both named %q, rename one of them since the manifest matches accounts by name"
+
+	//ErrorApplyFailed error
+	ErrorApplyFailed = "%s %s failed: %s (%d created, %d updated, %d deleted before it)"
+)
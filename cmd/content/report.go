@@ -0,0 +1,65 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdReportUse report cmd
+	CmdReportUse = "report"
+
+	//CmdReportShort short description
+	CmdReportShort = "Generate reports for auditors"
+
+	//CmdReportLong long description
+	CmdReportLong = `Generate reports of the findings of your cloud accounts for auditors.`
+
+	//CmdReportComplianceUse report compliance cmd
+	CmdReportComplianceUse = "compliance"
+
+	//CmdReportComplianceShort short description
+	CmdReportComplianceShort = "Generate an HTML compliance report for a framework"
+
+	//CmdReportComplianceLong long description
+	CmdReportComplianceLong = `Generate a single HTML file, viewable offline, with the pass or fail result of
+each control of a compliance framework across the selected cloud accounts and
+the resources failing it. A control fails when any rule mapped to it has an
+open finding. The report is stamped with the time it was generated and the
+profile and endpoint used. Rule mappings are read from the cached rule catalog,
+see 'vss rules'.`
+
+	//CmdReportComplianceExample examples
+	CmdReportComplianceExample = `  vss report compliance --framework "CIS AWS Foundations"
+  vss report compliance --framework PCI --cloud-id CLOUD_ID_1,CLOUD_ID_2 -o pci.html`
+
+	//CmdFlagReportFrameworkDescription framework flag description
+	CmdFlagReportFrameworkDescription = "Compliance framework to report on, as named in 'vss rules list', required"
+
+	//CmdFlagReportCloudIDDescription cloud id flag description
+	CmdFlagReportCloudIDDescription = "Only report on these Secure State cloud account IDs, by default all of them"
+
+	//CmdFlagReportOutputDescription output flag description
+	CmdFlagReportOutputDescription = "HTML file to write, default FRAMEWORK-compliance-DATE.html"
+
+	//DefaultComplianceReportFile is the report file name when --output is not given
+	DefaultComplianceReportFile = "%s-compliance-%s.html"
+
+	//ErrorFrameworkRequired error
+	ErrorFrameworkRequired = "A compliance framework is required. Use flag '--framework'\n"
+
+	//ErrorUnknownFramework error
+	ErrorUnknownFramework = "No rules map to framework %q, known frameworks are: %s"
+
+	//InfoComplianceReportWritten is printed after the report was written
+	InfoComplianceReportWritten = "Wrote %s: %d controls passed, %d failed\n"
+)
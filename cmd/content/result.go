@@ -19,10 +19,10 @@ const (
 	CmdResultUse = "result"
 
 	//CmdResultShort ...
-	CmdResultShort = "Show the violation results (Deprecated, please follow the link to swagger API doc https://api.securestate.vmware.com)"
+	CmdResultShort = "Show the violation results (Deprecated, please use vss findings list)"
 
 	//CmdResultLong ...
-	CmdResultLong = "Show the violation results (Deprecated, please follow the link to swagger API doc https://api.securestate.vmware.com)"
+	CmdResultLong = "Show the violation results (Deprecated, please use vss findings list)"
 
 	//CmdResultRuleUse ...
 	CmdResultRuleUse = "rule"
@@ -41,4 +41,10 @@ const (
 
 	//CmdResultObjectLong ...
 	CmdResultObjectLong = "Show violating objects (Deprecated)"
+
+	//CmdResultDeprecated tells where the result commands went
+	CmdResultDeprecated = "use 'vss findings list' instead"
+
+	//InfoResultMoved is printed by the deprecated result commands
+	InfoResultMoved = "Findings results have moved, please use `vss findings list`\n"
 )
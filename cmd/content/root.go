@@ -21,12 +21,51 @@ const (
 	//TeamID team id
 	TeamID = "TEAM_ID"
 
+	//AuthType profile key telling how the profile authenticates
+	AuthType = "AUTH_TYPE"
+
+	//AuthTypeAPIToken profiles authenticate with a user API token
+	AuthTypeAPIToken = "api_token"
+
+	//AuthTypeOAuthApp profiles authenticate as a CSP OAuth app
+	AuthTypeOAuthApp = "oauth_app"
+
+	//ClientID oauth app client id profile key
+	ClientID = "CLIENT_ID"
+
+	//ClientSecret oauth app client secret profile key
+	ClientSecret = "CLIENT_SECRET"
+
+	//OrgID oauth app organization id profile key
+	OrgID = "ORG_ID"
+
+	//Proxy proxy url profile key
+	Proxy = "PROXY"
+
+	//CABundle ca bundle profile key
+	CABundle = "CA_BUNDLE"
+
+	//ClientCert client certificate profile key
+	ClientCert = "CLIENT_CERT"
+
+	//ClientKey client key profile key
+	ClientKey = "CLIENT_KEY"
+
+	//Insecure insecure profile key
+	Insecure = "INSECURE"
+
+	//CSPURL csp url
+	CSPURL = "CSP_URL"
+
 	//DefaultFolder default folder
 	DefaultFolder = ".vss"
 
 	//DefaultFile default file
 	DefaultFile = "profiles.yaml"
 
+	//TokenCacheFolder folder under the config home for cached access tokens
+	TokenCacheFolder = "tokens"
+
 	//None none
 	None = "None"
 
@@ -36,6 +75,12 @@ const (
 	//Mask mask
 	Mask = "****************"
 
+	//ErrorClientIDMissing error
+	ErrorClientIDMissing = "The profile is an OAuth app profile without CLIENT_ID, run 'vss configure --client-id ID' to set it"
+
+	//ErrorClientSecretMissing error
+	ErrorClientSecretMissing = "Client secret of the OAuth app is required, use --client-secret, $VSS_CLIENT_SECRET or run 'vss configure --client-id ID'"
+
 	//ErrorAPIKeyMissing error
 	ErrorAPIKeyMissing = "VMware Secure State API Key is required for this command. Use flag --api-key\n"
 
@@ -129,6 +174,12 @@ const (
 	//CmdFlagVerboseDescription verbose flag description
 	CmdFlagVerboseDescription = "Enable verbose output"
 
+	//CmdFlagNoTokenCacheLong no token cache flag long
+	CmdFlagNoTokenCacheLong = "no-token-cache"
+
+	//CmdFlagNoTokenCacheDescription no token cache flag description
+	CmdFlagNoTokenCacheDescription = "Do not reuse or store CSP access tokens under the config home"
+
 	//CmdFlagFileLong JSON file flag
 	CmdFlagFileLong = "file"
 
@@ -153,6 +204,117 @@ const (
 	//CmdFlagAPIEndpointDescription api endpoint description
 	CmdFlagAPIEndpointDescription = "VMware Secure State API endpoint. Overrides $VSS_API_ENDPOINT."
 
+	//CmdFlagCSPURLLong csp url flag long
+	CmdFlagCSPURLLong = "csp-url"
+
+	//CmdFlagCSPURLDescription csp url flag description
+	CmdFlagCSPURLDescription = "VMware Cloud Services URL the API token is exchanged with. Overrides $VSS_CSP_URL and the profile's CSP_URL."
+
+	//CmdFlagRetriesLong retries flag long
+	CmdFlagRetriesLong = "retries"
+
+	//CmdFlagRetriesDescription retries flag description
+	CmdFlagRetriesDescription = "Number of times a request failing with 429, 5xx or a network error is retried, 0 disables retries"
+
+	//CmdFlagRetryMaxTimeLong retry max time flag long
+	CmdFlagRetryMaxTimeLong = "retry-max-time"
+
+	//CmdFlagRetryMaxTimeDescription retry max time flag description
+	CmdFlagRetryMaxTimeDescription = "Total time a request and its retries may take, e.g. 90s or 5m"
+
+	//CmdFlagDebugLong debug flag long
+	CmdFlagDebugLong = "debug"
+
+	//CmdFlagDebugDescription debug flag description
+	CmdFlagDebugDescription = "Print every API and CSP request and response to stderr, with credentials redacted"
+
+	//CmdFlagHARLong har flag long
+	CmdFlagHARLong = "har"
+
+	//CmdFlagHARDescription har flag description
+	CmdFlagHARDescription = "Write every API and CSP request and response to this HTTP Archive (HAR) file, with credentials redacted"
+
+	//ErrorWritingHAR is printed when the HAR file cannot be written
+	ErrorWritingHAR = "Error writing HAR file: %s\n"
+
+	//CmdFlagClientIDLong client id flag long
+	CmdFlagClientIDLong = "client-id"
+
+	//CmdFlagClientIDDescription client id flag description
+	CmdFlagClientIDDescription = "Client ID of a CSP OAuth app to authenticate with instead of an API token"
+
+	//CmdFlagClientSecretLong client secret flag long
+	CmdFlagClientSecretLong = "client-secret"
+
+	//CmdFlagClientSecretDescription client secret flag description
+	CmdFlagClientSecretDescription = "Client secret of the CSP OAuth app. Overrides $VSS_CLIENT_SECRET."
+
+	//CmdFlagOrgIDLong org id flag long
+	CmdFlagOrgIDLong = "org-id"
+
+	//CmdFlagOrgIDDescription org id flag description
+	CmdFlagOrgIDDescription = "CSP organization ID the OAuth app gets its token for, optional"
+
+	//CmdFlagRecordLong record flag long
+	CmdFlagRecordLong = "record"
+
+	//CmdFlagRecordDescription record flag description
+	CmdFlagRecordDescription = "Save every API and CSP request and response to this directory, with credentials redacted, for --replay"
+
+	//CmdFlagReplayLong replay flag long
+	CmdFlagReplayLong = "replay"
+
+	//CmdFlagReplayDescription replay flag description
+	CmdFlagReplayDescription = "Answer API and CSP requests from a directory saved with --record instead of the network, no API key is needed"
+
+	//ErrorRecordAndReplay is returned when both --record and --replay are given
+	ErrorRecordAndReplay = "--record and --replay cannot be used together"
+
+	//ErrorWritingCassette is printed when an interaction cannot be recorded
+	ErrorWritingCassette = "Error recording interactions: %s\n"
+
+	//CmdFlagProxyLong proxy flag long
+	CmdFlagProxyLong = "proxy"
+
+	//CmdFlagProxyDescription proxy flag description
+	CmdFlagProxyDescription = "URL of the proxy all requests go through, e.g. http://proxy.corp:3128. Overrides $HTTPS_PROXY and the profile's PROXY."
+
+	//CmdFlagCABundleLong ca bundle flag long
+	CmdFlagCABundleLong = "ca-bundle"
+
+	//CmdFlagCABundleDescription ca bundle flag description
+	CmdFlagCABundleDescription = "PEM file of certificate authorities to trust in addition to the system ones. Overrides the profile's CA_BUNDLE."
+
+	//CmdFlagClientCertLong client cert flag long
+	CmdFlagClientCertLong = "client-cert"
+
+	//CmdFlagClientCertDescription client cert flag description
+	CmdFlagClientCertDescription = "PEM client certificate for mutual TLS, requires --client-key. Overrides the profile's CLIENT_CERT."
+
+	//CmdFlagClientKeyLong client key flag long
+	CmdFlagClientKeyLong = "client-key"
+
+	//CmdFlagClientKeyDescription client key flag description
+	CmdFlagClientKeyDescription = "PEM key of --client-cert. Overrides the profile's CLIENT_KEY."
+
+	//CmdFlagInsecureLong insecure flag long
+	CmdFlagInsecureLong = "insecure"
+
+	//CmdFlagInsecureDescription insecure flag description
+	CmdFlagInsecureDescription = "Do not verify server certificates, only for local test servers. Can also be set with the profile's INSECURE."
+
+	//InfoInsecure warns that certificates are not verified
+	InfoInsecure = "Warning: server certificates are not verified (--insecure)\n"
+
+	//CmdFlagTimeoutLong timeout flag long
+	CmdFlagTimeoutLong = "timeout"
+
+	//CmdFlagTimeoutDescription timeout flag description
+	CmdFlagTimeoutDescription = "Cancel the command if it has not finished after this long, e.g. 30s or 10m, 0 waits forever"
+
+	//InfoCancelling is printed when the first interrupt signal is received
+	InfoCancelling = "\nCancelling, press Ctrl-C again to exit immediately\n"
+
 	//CmdCoreoUse Coreo cmd
 	CmdCoreoUse = "vss"
 
@@ -171,6 +333,9 @@ const (
 	//CmdAddUse add cmd
 	CmdAddUse = "add"
 
+	//CmdCreateUse create cmd
+	CmdCreateUse = "create"
+
 	//CmdUpdateUse update cmd
 	CmdUpdateUse = "update"
 
@@ -0,0 +1,127 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdRulesUse rules cmd
+	CmdRulesUse = "rules"
+
+	//CmdRulesShort short description
+	CmdRulesShort = "Browse the rule catalog"
+
+	//CmdRulesLong long description
+	CmdRulesLong = `Browse the rules that produce findings. The catalog is cached per profile under
+$VSS_HOME/cache for --cache-ttl, so that lookups and shell completion of rule
+IDs are fast and work offline.`
+
+	//CmdRulesListShort short description
+	CmdRulesListShort = "List rules"
+
+	//CmdRulesListLong long description
+	CmdRulesListLong = `List the rules matching all of the given filters with their provider, service,
+severity and the compliance frameworks they map to. Each filter flag takes a
+comma separated list of values, or can be repeated, and matches any of them.`
+
+	//CmdRulesListExample examples
+	CmdRulesListExample = `  vss rules list --provider aws --severity high
+  vss rules list --framework "CIS AWS Foundations" --service s3,iam`
+
+	//CmdRulesShowShort short description
+	CmdRulesShowShort = "Show a rule"
+
+	//CmdRulesShowLong long description
+	CmdRulesShowLong = `Show the full detail of a rule, including its description, the compliance
+controls it maps to and how to remediate its findings.`
+
+	//CmdRulesShowExample examples
+	CmdRulesShowExample = `  vss rules show --rule-id RULE_ID`
+
+	//CmdFlagCacheTTLLong cache ttl flag long
+	CmdFlagCacheTTLLong = "cache-ttl"
+
+	//CmdFlagCacheTTLDescription cache ttl flag description
+	CmdFlagCacheTTLDescription = "How long the cached rule catalog is used before it is fetched again"
+
+	//CmdFlagRefreshLong refresh flag long
+	CmdFlagRefreshLong = "refresh"
+
+	//CmdFlagRefreshDescription refresh flag description
+	CmdFlagRefreshDescription = "Fetch the rule catalog even if the cache is fresh"
+
+	//CmdFlagOfflineLong offline flag long
+	CmdFlagOfflineLong = "offline"
+
+	//CmdFlagOfflineDescription offline flag description
+	CmdFlagOfflineDescription = "Only use the cached rule catalog, however old it is. No credentials are needed"
+
+	//CmdFlagServiceLong service flag long
+	CmdFlagServiceLong = "service"
+
+	//CmdFlagServiceDescription service flag description
+	CmdFlagServiceDescription = "Only rules of these cloud services, e.g. s3"
+
+	//CmdFlagFrameworkLong framework flag long
+	CmdFlagFrameworkLong = "framework"
+
+	//CmdFlagFrameworkDescription framework flag description
+	CmdFlagFrameworkDescription = "Only rules mapped to these compliance frameworks"
+
+	//CmdFlagRuleSeverityDescription rule severity flag description
+	CmdFlagRuleSeverityDescription = "Only rules of these severities: High, Medium, Low"
+
+	//CmdFlagRuleProviderDescription rule provider flag description
+	CmdFlagRuleProviderDescription = "Only rules of these cloud providers: AWS, Azure"
+
+	//CmdFlagQuietLong quiet flag long
+	CmdFlagQuietLong = "quiet"
+
+	//CmdFlagQuietDescription quiet flag description
+	CmdFlagQuietDescription = "Only print the rule IDs, one per line"
+
+	//CmdFlagShowRuleIDDescription rule id flag description
+	CmdFlagShowRuleIDDescription = "ID of the rule to show"
+
+	//RuleCacheFolder folder under the config home for the cached rule catalog
+	RuleCacheFolder = "cache"
+
+	//RuleIDCompletionFunc is the bash function completing rule IDs
+	RuleIDCompletionFunc = "__vss_get_rule_ids"
+
+	//BashCompletionFunction is added to the bash completion script, it completes
+	//rule IDs from the cached rule catalog
+	BashCompletionFunction = `__vss_get_rule_ids()
+{
+    local vss_out
+    if vss_out=$(vss rules list --offline --quiet 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${vss_out[*]}" -- "$cur" ) )
+    fi
+}
+`
+
+	//ErrorRuleIDRequired error
+	ErrorRuleIDRequired = "Rule ID is required for this command. Use flag '--rule-id'\n"
+
+	//ErrorNoRuleCache error
+	ErrorNoRuleCache = "No cached rule catalog, run 'vss rules list' without --offline first"
+
+	//ErrorNoRuleWithIDFound error
+	ErrorNoRuleWithIDFound = "No rule with ID %s found in the cached rule catalog"
+
+	//ErrorWritingRuleCache is printed with --verbose when the rule catalog could not be cached
+	ErrorWritingRuleCache = "Could not cache the rule catalog: %s\n"
+
+	//InfoNoRules is printed when no rule matches the filters
+	InfoNoRules = "No rules found."
+)
@@ -0,0 +1,141 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+const (
+	//CmdSuppressionsUse suppressions cmd
+	CmdSuppressionsUse = "suppressions"
+
+	//CmdSuppressionsShort short description
+	CmdSuppressionsShort = "Manage finding suppressions"
+
+	//CmdSuppressionsLong long description
+	CmdSuppressionsLong = `Manage suppressions, the exceptions that record a finding as an accepted risk.
+A suppression is scoped to a rule, an object, a cloud account or any
+combination of them, and expires at a given date.`
+
+	//CmdSuppressionsListShort short description
+	CmdSuppressionsListShort = "List suppressions"
+
+	//CmdSuppressionsListLong long description
+	CmdSuppressionsListLong = `List all suppressions, soonest expiring first.`
+
+	//CmdSuppressionsCreateShort short description
+	CmdSuppressionsCreateShort = "Create a suppression"
+
+	//CmdSuppressionsCreateLong long description
+	CmdSuppressionsCreateLong = `Create a suppression for the findings matching all of --rule-id, --object-id
+and --cloud-id that are given, at least one of them is required. A
+justification, an owner and an expiry date are required too.`
+
+	//CmdSuppressionsCreateExample examples
+	CmdSuppressionsCreateExample = `  vss suppressions create --rule-id RULE_ID --cloud-id CLOUD_ID \
+    --justification "Public website bucket" --owner web-team@example.com --expires 2026-12-31`
+
+	//CmdSuppressionsDeleteShort short description
+	CmdSuppressionsDeleteShort = "Delete a suppression"
+
+	//CmdSuppressionsDeleteLong long description
+	CmdSuppressionsDeleteLong = `Delete a suppression, its findings are reported again.`
+
+	//CmdSuppressionsExpiringUse suppressions expiring cmd
+	CmdSuppressionsExpiringUse = "expiring"
+
+	//CmdSuppressionsExpiringShort short description
+	CmdSuppressionsExpiringShort = "Report suppressions expiring soon"
+
+	//CmdSuppressionsExpiringLong long description
+	CmdSuppressionsExpiringLong = `Report the suppressions that expire within --days days, and those that have
+already expired, soonest expiring first, so that their owners can renew or
+fix them in time.`
+
+	//CmdFlagSuppressionIDLong suppression id flag long
+	CmdFlagSuppressionIDLong = "suppression-id"
+
+	//CmdFlagSuppressionIDDescription suppression id flag description
+	CmdFlagSuppressionIDDescription = "Secure State suppression ID"
+
+	//CmdFlagSuppressionRuleIDDescription rule id flag description
+	CmdFlagSuppressionRuleIDDescription = "Suppress the findings of this rule ID"
+
+	//CmdFlagObjectIDLong object id flag long
+	CmdFlagObjectIDLong = "object-id"
+
+	//CmdFlagSuppressionObjectIDDescription object id flag description
+	CmdFlagSuppressionObjectIDDescription = "Suppress the findings of this object ID"
+
+	//CmdFlagSuppressionCloudIDDescription cloud id flag description
+	CmdFlagSuppressionCloudIDDescription = "Suppress the findings of this Secure State cloud account ID"
+
+	//CmdFlagJustificationLong justification flag long
+	CmdFlagJustificationLong = "justification"
+
+	//CmdFlagJustificationDescription justification flag description
+	CmdFlagJustificationDescription = "Why the risk is accepted, required"
+
+	//CmdFlagOwnerLong owner flag long
+	CmdFlagOwnerLong = "owner"
+
+	//CmdFlagOwnerDescription owner flag description
+	CmdFlagOwnerDescription = "Who is responsible for the accepted risk, required"
+
+	//CmdFlagExpiresLong expires flag long
+	CmdFlagExpiresLong = "expires"
+
+	//CmdFlagExpiresDescription expires flag description
+	CmdFlagExpiresDescription = "Expiry date as YYYY-MM-DD (start of the day, UTC) or RFC 3339 time, required"
+
+	//CmdFlagDaysLong days flag long
+	CmdFlagDaysLong = "days"
+
+	//CmdFlagDaysDescription days flag description
+	CmdFlagDaysDescription = "Report suppressions expiring within this many days"
+
+	//ErrorSuppressionScopeRequired error
+	ErrorSuppressionScopeRequired = "At least one of '--rule-id', '--object-id' and '--cloud-id' is required to scope the suppression\n"
+
+	//ErrorJustificationRequired error
+	ErrorJustificationRequired = "A justification is required. Use flag '--justification'\n"
+
+	//ErrorOwnerRequired error
+	ErrorOwnerRequired = "An owner is required. Use flag '--owner'\n"
+
+	//ErrorExpiresRequired error
+	ErrorExpiresRequired = "An expiry date is required. Use flag '--expires'\n"
+
+	//ErrorInvalidExpires error
+	ErrorInvalidExpires = "Invalid expiry date %q, use YYYY-MM-DD or an RFC 3339 time"
+
+	//ErrorExpiresInPast error
+	ErrorExpiresInPast = "Expiry date %s is in the past"
+
+	//ErrorInvalidDays error
+	ErrorInvalidDays = "--days cannot be negative"
+
+	//ErrorSuppressionIDRequired error
+	ErrorSuppressionIDRequired = "Suppression ID is required for this command. Use flag '--suppression-id'\n"
+
+	//InfoUsingSuppressionID info
+	InfoUsingSuppressionID = "[ OK ] Using Suppression ID %s\n"
+
+	//InfoSuppressionDeleted info
+	InfoSuppressionDeleted = "Suppression deleted successfully!"
+
+	//InfoNoSuppressions is printed when there are no suppressions to list
+	InfoNoSuppressions = "No suppressions found."
+
+	//InfoNoExpiringSuppressions is printed when no suppression expires soon
+	InfoNoExpiringSuppressions = "No suppressions expire within %d days.\n"
+)
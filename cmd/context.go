@@ -0,0 +1,94 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+)
+
+// rollbackTimeout bounds the clean up done after a command was cancelled
+const rollbackTimeout = time.Minute
+
+var (
+	// commandCtx is cancelled on SIGINT/SIGTERM or when --timeout expires,
+	// every API and cloud SDK call made by a command uses it
+	commandCtx    = context.Background()
+	cancelCommand = func() {}
+)
+
+// cancelOnSignal cancels commandCtx on the first SIGINT or SIGTERM and exits
+// on the second one. The returned func releases the signal handler.
+func cancelOnSignal() func() {
+	ctx, cancel := context.WithCancel(commandCtx)
+	commandCtx, cancelCommand = ctx, cancel
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		if _, ok := <-signals; !ok {
+			return
+		}
+		fmt.Fprint(os.Stderr, content.InfoCancelling)
+		cancel()
+		if _, ok := <-signals; ok {
+			os.Exit(exitInterrupted)
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		cancelCommand()
+	}
+}
+
+// applyTimeout limits commandCtx to d, a zero duration means no limit
+func applyTimeout(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(commandCtx, d)
+	parent := cancelCommand
+	commandCtx = ctx
+	cancelCommand = func() {
+		cancel()
+		parent()
+	}
+}
+
+// rollbackContext returns a context for undoing partial work. It is not tied
+// to commandCtx so that the clean up still runs after the command was cancelled.
+func rollbackContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), rollbackTimeout)
+}
+
+// sleepOrCancel waits for d, returning early with the error of commandCtx
+// when the command is cancelled first
+func sleepOrCancel(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-commandCtx.Done():
+		return commandCtx.Err()
+	}
+}
@@ -19,29 +19,57 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/CloudCoreo/cli/client"
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
 	hostEnvVar         = "VSS_API_ENDPOINT"
+	cspURLEnvVar       = "VSS_CSP_URL"
+	clientSecretEnvVar = "VSS_CLIENT_SECRET"
 	homeEnvVar         = "VSS_HOME"
 	profileEnvVar      = "VSS_PROFILE"
+	hecTokenEnvVar     = "VSS_HEC_TOKEN"
+	jiraUserEnvVar     = "VSS_JIRA_USER"
+	jiraTokenEnvVar    = "VSS_JIRA_TOKEN"
 	defaultAPIEndpoint = "https://app.securestate.vmware.com/api"
 	defaultProfile     = "default"
 )
 
 var (
-	coreoHome   string
-	userProfile string
-	key         string
-	teamID      string
-	apiEndpoint string
-	jsonFormat  bool
-	verbose     bool
+	coreoHome    string
+	userProfile  string
+	key          string
+	teamID       string
+	apiEndpoint  string
+	cspURL       string
+	clientID     string
+	clientSecret string
+	orgID        string
+	jsonFormat   bool
+	verbose      bool
+	noCache      bool
+	retries      int
+	retryTime    time.Duration
+	timeout      time.Duration
+	debug        bool
+	harFile      string
+	proxy        string
+	caBundle     string
+	clientCert   string
+	clientKey    string
+	insecure     bool
+	recordDir    string
+	replayDir    string
+
+	// har records the session when --har is set, it is written when the command exits
+	har *client.HAR
 )
 
 func newRootCmd(out io.Writer) *cobra.Command {
@@ -52,6 +80,8 @@ func newRootCmd(out io.Writer) *cobra.Command {
 		Short:        content.CmdCoreoShort,
 		Long:         content.CmdCoreoLong,
 		SilenceUsage: true,
+
+		BashCompletionFunction: content.BashCompletionFunction,
 	}
 
 	userProfileToUse := os.Getenv(profileEnvVar)
@@ -70,8 +100,25 @@ func newRootCmd(out io.Writer) *cobra.Command {
 	p.StringVar(&key, content.CmdFlagAPIKeyLong, content.None, content.CmdFlagAPIKeyDescription)
 	p.StringVar(&teamID, content.CmdFlagTeamIDLong, content.None, content.CmdFlagTeamIDDescription)
 	p.StringVar(&apiEndpoint, content.CmdFlagAPIEndpointLong, envAPIEndpoint, content.CmdFlagAPIEndpointDescription)
+	p.StringVar(&cspURL, content.CmdFlagCSPURLLong, os.Getenv(cspURLEnvVar), content.CmdFlagCSPURLDescription)
+	p.StringVar(&clientID, content.CmdFlagClientIDLong, "", content.CmdFlagClientIDDescription)
+	p.StringVar(&clientSecret, content.CmdFlagClientSecretLong, os.Getenv(clientSecretEnvVar), content.CmdFlagClientSecretDescription)
+	p.StringVar(&orgID, content.CmdFlagOrgIDLong, "", content.CmdFlagOrgIDDescription)
 	p.BoolVar(&jsonFormat, content.CmdFlagJSONLong, false, content.CmdFlagJSONDescription)
 	p.BoolVar(&verbose, content.CmdFlagVerboseLong, false, content.CmdFlagVerboseDescription)
+	p.IntVar(&retries, content.CmdFlagRetriesLong, client.DefaultRetryPolicy.MaxRetries, content.CmdFlagRetriesDescription)
+	p.DurationVar(&retryTime, content.CmdFlagRetryMaxTimeLong, client.DefaultRetryPolicy.MaxElapsed, content.CmdFlagRetryMaxTimeDescription)
+	p.BoolVar(&noCache, content.CmdFlagNoTokenCacheLong, false, content.CmdFlagNoTokenCacheDescription)
+	p.DurationVar(&timeout, content.CmdFlagTimeoutLong, 0, content.CmdFlagTimeoutDescription)
+	p.BoolVar(&debug, content.CmdFlagDebugLong, false, content.CmdFlagDebugDescription)
+	p.StringVar(&harFile, content.CmdFlagHARLong, "", content.CmdFlagHARDescription)
+	p.StringVar(&proxy, content.CmdFlagProxyLong, "", content.CmdFlagProxyDescription)
+	p.StringVar(&caBundle, content.CmdFlagCABundleLong, "", content.CmdFlagCABundleDescription)
+	p.StringVar(&clientCert, content.CmdFlagClientCertLong, "", content.CmdFlagClientCertDescription)
+	p.StringVar(&clientKey, content.CmdFlagClientKeyLong, "", content.CmdFlagClientKeyDescription)
+	p.BoolVar(&insecure, content.CmdFlagInsecureLong, false, content.CmdFlagInsecureDescription)
+	p.StringVar(&recordDir, content.CmdFlagRecordLong, "", content.CmdFlagRecordDescription)
+	p.StringVar(&replayDir, content.CmdFlagReplayLong, "", content.CmdFlagReplayDescription)
 	cmd.AddCommand(
 		newVersionCmd(out),
 		newTeamCmd(out),
@@ -83,15 +130,31 @@ func newRootCmd(out io.Writer) *cobra.Command {
 		// Hidden documentation generator command: 'coreo docs'
 		newDocsCmd(out),
 		newEventCmd(out),
+		newAPICmd(nil, out),
+		newFindingsCmd(out),
+		newSuppressionsCmd(out),
+		newNotificationsCmd(out),
+		newRulesCmd(out),
+		newReportCmd(out),
+		newPlanCmd(nil, out),
+		newApplyCmd(nil, out),
 	)
 
 	return cmd
 }
 
 func main() {
+	stop := cancelOnSignal()
+	defer stop()
+
 	cmd := newRootCmd(os.Stdout)
-	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+	err := cmd.Execute()
+	writeHAR()
+	checkRecording()
+	if err != nil {
+		code := commandExitCode(err)
+		stop()
+		os.Exit(code)
 	}
 }
 
@@ -124,13 +187,39 @@ func setupCoreoConfig(cmd *cobra.Command, args []string) error {
 }
 
 func setupCoreoCredentials(cmd *cobra.Command, args []string) error {
-	apiKey, err := util.CheckAPIKeyFlag(key, userProfile)
-
-	if err != nil {
+	if err := setupCassette(); err != nil {
 		return err
+	}
 
+	if key == content.None {
+		// An API key given on the command line wins over an OAuth app profile.
+		id, secret, org, err := util.CheckClientCredentialsFlag(clientID, clientSecret, orgID, userProfile)
+		if err != nil && player == nil {
+			return err
+		}
+		clientID, clientSecret, orgID = id, secret, org
+	} else {
+		clientID = ""
+	}
+
+	if clientID == "" || player != nil {
+		apiKey, err := util.CheckAPIKeyFlag(key, userProfile)
+
+		if err != nil && player == nil {
+			return err
+
+		}
+		if err != nil {
+			apiKey = replayAPIKey
+		}
+		key = apiKey
+	}
+	cspURL = util.CheckCSPURLFlag(cspURL, userProfile)
+	applyTimeout(timeout)
+
+	if err := setupTransport(); err != nil {
+		return err
 	}
-	key = apiKey
 
 	if verbose {
 		fmt.Printf(content.InfoUsingProfile, userProfile)
@@ -139,6 +228,63 @@ func setupCoreoCredentials(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newCoreoClient returns a coreo client configured from the global flags and profile
+func newCoreoClient() *coreo.Client {
+	opts := []coreo.Option{
+		coreo.Host(apiEndpoint),
+		coreo.RefreshToken(key),
+		coreo.CSPURL(cspURL),
+	}
+	if clientID != "" && player == nil {
+		opts = append(opts, coreo.ClientCredentials(clientID, clientSecret, orgID))
+	}
+
+	retry := client.DefaultRetryPolicy
+	retry.MaxRetries = retries
+	retry.MaxElapsed = retryTime
+	opts = append(opts, coreo.Retry(retry))
+
+	logger := func(format string, v ...interface{}) {
+		fmt.Fprintf(os.Stderr, format, v...)
+	}
+	if verbose || debug {
+		opts = append(opts, coreo.Logger(logger))
+	}
+	if debug {
+		opts = append(opts, coreo.Tracer(client.DebugTracer(logger)))
+	}
+	if harFile != "" {
+		if har == nil {
+			har = client.NewHAR(content.CmdCoreoUse, version)
+		}
+		opts = append(opts, coreo.Tracer(har.Trace))
+	}
+
+	if recorder != nil {
+		opts = append(opts, coreo.Tracer(recorder.Record))
+	}
+	if player != nil {
+		opts = append(opts, coreo.Transport(player))
+	}
+
+	// A cached access token would leave the CSP token exchange out of recordings.
+	if !noCache && recorder == nil && player == nil {
+		cacheDir := filepath.Join(homePath(), content.TokenCacheFolder)
+		opts = append(opts, coreo.TokenCache(client.NewFileTokenCache(cacheDir, userProfile)))
+	}
+	return coreo.NewClient(opts...)
+}
+
+// writeHAR saves the requests recorded for --har, also when the command failed
+func writeHAR() {
+	if har == nil {
+		return
+	}
+	if err := har.WriteFile(harFile); err != nil {
+		fmt.Fprintf(os.Stderr, content.ErrorWritingHAR, err)
+	}
+}
+
 func defaultCoreoHome() string {
 	if home := os.Getenv(homeEnvVar); home != "" {
 		return home
@@ -15,6 +15,11 @@
 package main
 
 import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
 	"github.com/CloudCoreo/cli/client"
 )
 
@@ -25,15 +30,52 @@ type fakeReleaseClient struct {
 	info             client.RoleCreationInfo
 	regions          []string
 	validationResult client.RoleReValidationResult
+	cloudFilter      client.CloudAccountFilter
+
+	findings      []*client.Finding
+	findingFilter client.FindingFilter
+
+	suppressions []*client.Suppression
+	created      *client.Suppression
+	deletedID    string
+
+	rules     []*client.Rule
+	ruleCalls int
+
+	notifications []*client.NotificationTarget
+	saved         *client.NotificationTarget
+	testResult    client.NotificationTestResult
+
+	// responses are returned by Call in turn, calls records what was asked
+	responses [][]byte
+	calls     []fakeCall
+}
+
+type fakeCall struct {
+	method string
+	path   string
+	header http.Header
+	body   string
 }
 
-func (c *fakeReleaseClient) ListCloudAccounts() ([]*client.CloudAccount, error) {
+func (c *fakeReleaseClient) ListCloudAccounts(ctx context.Context) ([]*client.CloudAccount, error) {
 	resp := c.cloudAccounts
 
 	return resp, c.err
 }
 
-func (c *fakeReleaseClient) ShowCloudAccountByID(cloudID string) (*client.CloudAccount, error) {
+func (c *fakeReleaseClient) QueryCloudAccounts(ctx context.Context, filter client.CloudAccountFilter) ([]*client.CloudAccount, error) {
+	c.cloudFilter = filter
+	resp := make([]*client.CloudAccount, 0)
+	for _, cloud := range c.cloudAccounts {
+		if filter.Match(cloud) {
+			resp = append(resp, cloud)
+		}
+	}
+	return resp, c.err
+}
+
+func (c *fakeReleaseClient) ShowCloudAccountByID(ctx context.Context, cloudID string) (*client.CloudAccount, error) {
 	resp := &client.CloudAccount{}
 	if len(c.cloudAccounts) > 0 {
 
@@ -43,7 +85,7 @@ func (c *fakeReleaseClient) ShowCloudAccountByID(cloudID string) (*client.CloudA
 	return resp, c.err
 }
 
-func (c *fakeReleaseClient) CreateCloudAccount(input *client.CreateCloudAccountInput) (*client.CloudAccount, error) {
+func (c *fakeReleaseClient) CreateCloudAccount(ctx context.Context, input *client.CreateCloudAccountInput) (*client.CloudAccount, error) {
 	resp := &client.CloudAccount{}
 	if len(c.cloudAccounts) > 0 {
 
@@ -53,17 +95,17 @@ func (c *fakeReleaseClient) CreateCloudAccount(input *client.CreateCloudAccountI
 	return resp, c.err
 }
 
-func (c *fakeReleaseClient) DeleteCloudAccountByID(cloudID string) error {
+func (c *fakeReleaseClient) DeleteCloudAccountByID(ctx context.Context, cloudID string) error {
 	return c.err
 }
 
-func (c *fakeReleaseClient) GetEventStreamConfig(cloudID string) (*client.EventStreamConfig, error) {
+func (c *fakeReleaseClient) GetEventStreamConfig(ctx context.Context, cloudID string) (*client.EventStreamConfig, error) {
 	return &client.EventStreamConfig{
 		AWSEventStreamConfig: client.AWSEventStreamConfig{Regions: c.regions},
 	}, c.err
 }
 
-func (c *fakeReleaseClient) GetEventRemoveConfig(cloudID string) (*client.EventRemoveConfig, error) {
+func (c *fakeReleaseClient) GetEventRemoveConfig(ctx context.Context, cloudID string) (*client.EventRemoveConfig, error) {
 	return &client.EventRemoveConfig{
 		AWSEventRemoveConfig: client.AWSEventRemoveConfig{
 			Regions: c.regions,
@@ -71,12 +113,12 @@ func (c *fakeReleaseClient) GetEventRemoveConfig(cloudID string) (*client.EventR
 	}, c.err
 }
 
-func (c *fakeReleaseClient) GetRoleCreationInfo(input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error) {
+func (c *fakeReleaseClient) GetRoleCreationInfo(ctx context.Context, input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error) {
 	resp := c.info
 	return &resp, c.err
 }
 
-func (c *fakeReleaseClient) UpdateCloudAccount(input *client.UpdateCloudAccountInput) (*client.CloudAccount, error) {
+func (c *fakeReleaseClient) UpdateCloudAccount(ctx context.Context, input *client.UpdateCloudAccountInput) (*client.CloudAccount, error) {
 	resp := &client.CloudAccount{}
 	if len(c.cloudAccounts) > 0 {
 
@@ -85,29 +127,126 @@ func (c *fakeReleaseClient) UpdateCloudAccount(input *client.UpdateCloudAccountI
 	return resp, c.err
 }
 
-func (c *fakeReleaseClient) ReValidateRole(cloudID string) (*client.RoleReValidationResult, error) {
+func (c *fakeReleaseClient) ReValidateRole(ctx context.Context, cloudID string) (*client.RoleReValidationResult, error) {
 	resp := c.validationResult
 	return &resp, c.err
 }
 
+func (c *fakeReleaseClient) ListFindings(ctx context.Context, filter client.FindingFilter, limit int) ([]*client.Finding, error) {
+	c.findingFilter = filter
+	resp := c.findings
+	if limit > 0 && len(resp) > limit {
+		resp = resp[:limit]
+	}
+	return resp, c.err
+}
+
+func (c *fakeReleaseClient) ListSuppressions(ctx context.Context) ([]*client.Suppression, error) {
+	return c.suppressions, c.err
+}
+
+func (c *fakeReleaseClient) CreateSuppression(ctx context.Context, suppression *client.Suppression) (*client.Suppression, error) {
+	c.created = suppression
+	resp := *suppression
+	resp.ID = "suppression-id"
+	return &resp, c.err
+}
+
+func (c *fakeReleaseClient) DeleteSuppression(ctx context.Context, suppressionID string) error {
+	c.deletedID = suppressionID
+	return c.err
+}
+
+func (c *fakeReleaseClient) ListRules(ctx context.Context) ([]*client.Rule, error) {
+	c.ruleCalls++
+	return c.rules, c.err
+}
+
+func (c *fakeReleaseClient) ShowRuleByID(ctx context.Context, ruleID string) (*client.Rule, error) {
+	c.ruleCalls++
+	for _, rule := range c.rules {
+		if rule.ID == ruleID {
+			return rule, c.err
+		}
+	}
+	if c.err == nil {
+		return nil, client.NewError("not found")
+	}
+	return nil, c.err
+}
+
+func (c *fakeReleaseClient) ListNotifications(ctx context.Context) ([]*client.NotificationTarget, error) {
+	return c.notifications, c.err
+}
+
+func (c *fakeReleaseClient) ShowNotificationByID(ctx context.Context, notificationID string) (*client.NotificationTarget, error) {
+	for _, target := range c.notifications {
+		if target.ID == notificationID {
+			resp := *target
+			return &resp, c.err
+		}
+	}
+	if c.err == nil {
+		return nil, client.NewError("not found")
+	}
+	return nil, c.err
+}
+
+func (c *fakeReleaseClient) CreateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error) {
+	c.saved = target
+	resp := *target
+	resp.ID = "notification-id"
+	return &resp, c.err
+}
+
+func (c *fakeReleaseClient) UpdateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error) {
+	c.saved = target
+	return target, c.err
+}
+
+func (c *fakeReleaseClient) DeleteNotification(ctx context.Context, notificationID string) error {
+	c.deletedID = notificationID
+	return c.err
+}
+
+func (c *fakeReleaseClient) TestNotification(ctx context.Context, notificationID string) (*client.NotificationTestResult, error) {
+	resp := c.testResult
+	return &resp, c.err
+}
+
+func (c *fakeReleaseClient) Call(ctx context.Context, method, path string, header http.Header, body io.Reader) ([]byte, error) {
+	call := fakeCall{method: method, path: path, header: header}
+	if body != nil {
+		b, _ := ioutil.ReadAll(body)
+		call.body = string(b)
+	}
+	c.calls = append(c.calls, call)
+
+	var resp []byte
+	if len(c.responses) > 0 {
+		resp, c.responses = c.responses[0], c.responses[1:]
+	}
+	return resp, c.err
+}
+
 type fakeCloudProvider struct {
 	err        error
 	arn        string
 	externalID string
 }
 
-func (c *fakeCloudProvider) SetupEventStream(input *client.EventStreamConfig) error {
+func (c *fakeCloudProvider) SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error {
 
 	return c.err
 }
 
-func (c *fakeCloudProvider) CreateNewRole(input *client.RoleCreationInfo) (arn string, externalID string, err error) {
+func (c *fakeCloudProvider) CreateNewRole(ctx context.Context, input *client.RoleCreationInfo) (arn string, externalID string, err error) {
 	return c.arn, c.externalID, c.err
 }
 
-func (c *fakeCloudProvider) DeleteRole(roleName string) {
+func (c *fakeCloudProvider) DeleteRole(ctx context.Context, roleName string) {
 
 }
-func (c *fakeCloudProvider) RemoveEventStream(input *client.EventRemoveConfig) error {
+func (c *fakeCloudProvider) RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error {
 	return c.err
 }
@@ -8,10 +8,6 @@ import (
 
 	"github.com/CloudCoreo/cli/pkg/aws"
 
-	"github.com/CloudCoreo/cli/pkg/coreo"
-
-	"github.com/CloudCoreo/cli/cmd/util"
-
 	"github.com/CloudCoreo/cli/cmd/content"
 
 	"github.com/CloudCoreo/cli/pkg/command"
@@ -25,9 +21,9 @@ type eventRemoveCmd struct {
 	out            io.Writer
 	awsProfile     string
 	awsProfilePath string
-	cloudID        string
-	authFile       string
-	region         string
+	cloudFlags
+	authFile string
+	region   string
 }
 
 func newEventRemoveCmd(client command.Interface, provider command.CloudProvider, out io.Writer) *cobra.Command {
@@ -43,14 +39,11 @@ func newEventRemoveCmd(client command.Interface, provider command.CloudProvider,
 		Long:    content.CmdEventRemoveLong,
 		Example: content.CmdEventRemoveExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Check for --cloud-id
-			if err := util.CheckCloudShowOrDeleteFlag(eventRemove.cloudID, verbose); err != nil {
-				return err
-			}
 			if eventRemove.client == nil {
-				eventRemove.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				eventRemove.client = newCoreoClient()
+			}
+			if err := eventRemove.resolveCloud(eventRemove.client); err != nil {
+				return err
 			}
 
 			return eventRemove.run()
@@ -59,7 +52,7 @@ func newEventRemoveCmd(client command.Interface, provider command.CloudProvider,
 	f := cmd.Flags()
 	f.StringVarP(&eventRemove.awsProfile, content.CmdFlagAwsProfile, "", "", content.CmdFlagAwsProfileDescription)
 	f.StringVarP(&eventRemove.awsProfilePath, content.CmdFlagAwsProfilePath, "", "", content.CmdFlagAwsProfilePathDescription)
-	f.StringVarP(&eventRemove.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	eventRemove.addFlags(f)
 	f.StringVarP(&eventRemove.authFile, content.CmdEventAuthFile, "", "", content.CmdEventAuthFileDescription)
 	f.StringVarP(&eventRemove.region, content.CmdEventRegion, "", "eastus", content.CmdEventRegionDescription)
 
@@ -67,7 +60,7 @@ func newEventRemoveCmd(client command.Interface, provider command.CloudProvider,
 }
 
 func (t *eventRemoveCmd) run() error {
-	config, err := t.client.GetEventRemoveConfig(t.cloudID)
+	config, err := t.client.GetEventRemoveConfig(commandCtx, t.cloudID)
 	if err != nil {
 		return err
 	}
@@ -93,7 +86,7 @@ func (t *eventRemoveCmd) run() error {
 		return errors.New("No regions returned")
 	}
 
-	err = t.cloud.RemoveEventStream(config)
+	err = t.cloud.RemoveEventStream(commandCtx, config)
 	if err != nil {
 		return err
 	}
@@ -7,21 +7,19 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/CloudCoreo/cli/cmd/content"
-	"github.com/CloudCoreo/cli/cmd/util"
 	"github.com/CloudCoreo/cli/pkg/aws"
 	"github.com/CloudCoreo/cli/pkg/azure"
 	"github.com/CloudCoreo/cli/pkg/command"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
 type eventSetupCmd struct {
-	client              command.Interface
-	cloud               command.CloudProvider
-	out                 io.Writer
-	awsProfile          string
-	awsProfilePath      string
-	cloudID             string
+	client         command.Interface
+	cloud          command.CloudProvider
+	out            io.Writer
+	awsProfile     string
+	awsProfilePath string
+	cloudFlags
 	ignoreMissingTrails bool
 	authFile            string
 	region              string
@@ -40,14 +38,11 @@ func newEventSetupCmd(client command.Interface, provider command.CloudProvider,
 		Long:    content.CmdEventSetupLong,
 		Example: content.CmdEventSetupExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Check for --cloud-id
-			if err := util.CheckCloudShowOrDeleteFlag(eventSetup.cloudID, verbose); err != nil {
-				return err
-			}
 			if eventSetup.client == nil {
-				eventSetup.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				eventSetup.client = newCoreoClient()
+			}
+			if err := eventSetup.resolveCloud(eventSetup.client); err != nil {
+				return err
 			}
 
 			return eventSetup.run()
@@ -56,7 +51,7 @@ func newEventSetupCmd(client command.Interface, provider command.CloudProvider,
 	f := cmd.Flags()
 	f.StringVarP(&eventSetup.awsProfile, content.CmdFlagAwsProfile, "", "", content.CmdFlagAwsProfileDescription)
 	f.StringVarP(&eventSetup.awsProfilePath, content.CmdFlagAwsProfilePath, "", "", content.CmdFlagAwsProfilePathDescription)
-	f.StringVarP(&eventSetup.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagCloudIDDescription)
+	eventSetup.addFlags(f)
 	f.BoolVarP(&eventSetup.ignoreMissingTrails, content.CmdFlagIgnoreMissingTrails, "", false, content.CmdFlagIgnoreMissingTrailsDescription)
 	f.StringVarP(&eventSetup.authFile, content.CmdEventAuthFile, "", "", content.CmdEventAuthFileDescription)
 	f.StringVarP(&eventSetup.region, content.CmdEventRegion, "", "eastus", content.CmdEventRegionDescription)
@@ -65,7 +60,7 @@ func newEventSetupCmd(client command.Interface, provider command.CloudProvider,
 
 func (t *eventSetupCmd) run() error {
 
-	config, err := t.client.GetEventStreamConfig(t.cloudID)
+	config, err := t.client.GetEventStreamConfig(commandCtx, t.cloudID)
 	if err != nil {
 		return err
 	}
@@ -93,7 +88,7 @@ func (t *eventSetupCmd) run() error {
 	if config.Provider == "AWS" && len(config.Regions) == 0 {
 		return errors.New("No regions returned")
 	}
-	err = t.cloud.SetupEventStream(config)
+	err = t.cloud.SetupEventStream(commandCtx, config)
 	if err != nil {
 		return err
 	}
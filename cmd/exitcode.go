@@ -0,0 +1,105 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+// Process exit codes, documented in the README so that scripts can rely on them
+const (
+	exitOK              = 0
+	exitError           = 1
+	exitGateFailed      = 2
+	exitAuthFailed      = 3
+	exitPermission      = 4
+	exitNotFound        = 5
+	exitRejected        = 6
+	exitRateLimited     = 7
+	exitServerError     = 8
+	exitNetworkError    = 9
+	exitUnexpectedReply = 10
+	exitTimeout         = 124
+	exitInterrupted     = 130
+)
+
+// exitCode maps the error a command returned to the process exit code
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return exitTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return exitInterrupted
+	}
+
+	var gateErr *gateFailedError
+	if errors.As(err, &gateErr) {
+		return exitGateFailed
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiExitCode(apiErr)
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return exitNetworkError
+	}
+
+	return exitError
+}
+
+// commandExitCode is exitCode, except that a command failing after it was
+// cancelled always reports the cancellation. The cloud SDKs return their own
+// error types for cancelled requests, so the error itself may not say so.
+func commandExitCode(err error) int {
+	if err != nil && commandCtx.Err() != nil {
+		return exitCode(commandCtx.Err())
+	}
+	return exitCode(err)
+}
+
+func apiExitCode(err *client.APIError) int {
+	if err.TokenExchange && err.StatusCode < 500 && err.StatusCode != http.StatusTooManyRequests {
+		// CSP rejects an invalid or expired API token with 400.
+		return exitAuthFailed
+	}
+
+	switch {
+	case err.StatusCode == http.StatusUnauthorized:
+		return exitAuthFailed
+	case err.StatusCode == http.StatusForbidden:
+		return exitPermission
+	case err.StatusCode == http.StatusNotFound:
+		return exitNotFound
+	case err.StatusCode == http.StatusTooManyRequests:
+		return exitRateLimited
+	case err.StatusCode >= 500:
+		return exitServerError
+	case err.StatusCode >= 400:
+		return exitRejected
+	}
+	return exitUnexpectedReply
+}
@@ -0,0 +1,54 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		code int
+	}{
+		{desc: "success", err: nil, code: exitOK},
+		{desc: "plain error", err: errors.New("Error"), code: exitError},
+		{desc: "unauthorized", err: &client.APIError{StatusCode: 401}, code: exitAuthFailed},
+		{desc: "invalid api token", err: &client.APIError{StatusCode: 400, TokenExchange: true}, code: exitAuthFailed},
+		{desc: "csp down", err: &client.APIError{StatusCode: 503, TokenExchange: true}, code: exitServerError},
+		{desc: "forbidden", err: &client.APIError{StatusCode: 403}, code: exitPermission},
+		{desc: "not found", err: &client.APIError{StatusCode: 404}, code: exitNotFound},
+		{desc: "bad request", err: &client.APIError{StatusCode: 400}, code: exitRejected},
+		{desc: "conflict", err: &client.APIError{StatusCode: 409}, code: exitRejected},
+		{desc: "throttled", err: &client.APIError{StatusCode: 429}, code: exitRateLimited},
+		{desc: "server error", err: &client.APIError{StatusCode: 502}, code: exitServerError},
+		{desc: "redirect", err: &client.APIError{StatusCode: 302}, code: exitUnexpectedReply},
+		{desc: "network error", err: &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("refused")}, code: exitNetworkError},
+		{desc: "timeout", err: &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded}, code: exitTimeout},
+		{desc: "interrupted", err: &client.InterruptedError{Operation: "Event stream setup", Err: context.Canceled}, code: exitInterrupted},
+		{desc: "gate failed", err: &gateFailedError{count: 2, threshold: "High"}, code: exitGateFailed},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, exitCode(tt.err), tt.desc)
+	}
+}
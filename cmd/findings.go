@@ -0,0 +1,42 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/spf13/cobra"
+)
+
+func newFindingsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               content.CmdFindingsUse,
+		Short:             content.CmdFindingsShort,
+		Long:              content.CmdFindingsLong,
+		PersistentPreRunE: setupCoreoConfig,
+	}
+
+	cmd.AddCommand(newFindingsListCmd(nil, out))
+	cmd.AddCommand(newFindingsExportCmd(nil, out))
+	cmd.AddCommand(newFindingsGateCmd(nil, out))
+	cmd.AddCommand(newFindingsBaselineCmd(out))
+	cmd.AddCommand(newFindingsSnapshotCmd(nil, out))
+	cmd.AddCommand(newFindingsDiffCmd(nil, out))
+	cmd.AddCommand(newFindingsForwardCmd(nil, out))
+	cmd.AddCommand(newFindingsSyncJiraCmd(nil, out))
+
+	return cmd
+}
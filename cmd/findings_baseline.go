@@ -0,0 +1,176 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/baseline"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// defaultBaselineFile is where baselines are read and written by default
+const defaultBaselineFile = "vss-baseline.json"
+
+// openFilter is filter, except that only open findings are matched unless
+// --status says otherwise. Suppressed and resolved findings never need a baseline.
+func (f *findingFilterFlags) openFilter() (client.FindingFilter, error) {
+	filter, err := f.filter()
+	if err != nil {
+		return filter, err
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []string{"Open"}
+	}
+	return filter, nil
+}
+
+func newFindingsBaselineCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   content.CmdFindingsBaselineUse,
+		Short: content.CmdFindingsBaselineShort,
+		Long:  content.CmdFindingsBaselineLong,
+	}
+
+	cmd.AddCommand(newBaselineCreateCmd(nil, out))
+	cmd.AddCommand(newBaselineUpdateCmd(nil, out))
+
+	return cmd
+}
+
+type baselineCreateCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	file  string
+	force bool
+}
+
+func newBaselineCreateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	baselineCreate := &baselineCreateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdBaselineCreateUse,
+		Short:   content.CmdBaselineCreateShort,
+		Long:    content.CmdBaselineCreateLong,
+		Example: content.CmdBaselineCreateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(baselineCreate.file); err == nil && !baselineCreate.force {
+				return fmt.Errorf(content.ErrorBaselineExists, baselineCreate.file)
+			}
+
+			if baselineCreate.client == nil {
+				baselineCreate.client = newCoreoClient()
+			}
+
+			return baselineCreate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	baselineCreate.addFlags(f)
+	f.StringVarP(&baselineCreate.file, content.CmdFlagBaselineLong, "", defaultBaselineFile, content.CmdFlagBaselineDescription)
+	f.BoolVarP(&baselineCreate.force, content.CmdFlagForceLong, "", false, content.CmdFlagBaselineForceDescription)
+
+	return cmd
+}
+
+func (t *baselineCreateCmd) run() error {
+	filter, err := t.openFilter()
+	if err != nil {
+		return err
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	b := baseline.New(findings)
+	if err := b.Save(t.file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(t.out, content.InfoBaselineCreated, t.file, len(b.Findings))
+	return nil
+}
+
+type baselineUpdateCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	file string
+}
+
+func newBaselineUpdateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	baselineUpdate := &baselineUpdateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdBaselineUpdateUse,
+		Short:   content.CmdBaselineUpdateShort,
+		Long:    content.CmdBaselineUpdateLong,
+		Example: content.CmdBaselineUpdateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if baselineUpdate.client == nil {
+				baselineUpdate.client = newCoreoClient()
+			}
+
+			return baselineUpdate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	baselineUpdate.addFlags(f)
+	f.StringVarP(&baselineUpdate.file, content.CmdFlagBaselineLong, "", defaultBaselineFile, content.CmdFlagBaselineDescription)
+
+	return cmd
+}
+
+func (t *baselineUpdateCmd) run() error {
+	old, err := baseline.Load(t.file)
+	if err != nil {
+		return err
+	}
+
+	filter, err := t.openFilter()
+	if err != nil {
+		return err
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	comparison := old.Compare(findings)
+	b := old.Update(findings, filter)
+	if err := b.Save(t.file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(t.out, content.InfoBaselineUpdated, t.file, len(comparison.New), len(comparison.FixedWithin(filter)), len(b.Findings))
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// diffNow names the current findings as a side of a diff
+const diffNow = "now"
+
+// diffSide is one of the two sets of findings a diff compares
+type diffSide struct {
+	Source   string    `json:"source"`
+	TakenAt  time.Time `json:"takenAt"`
+	findings []*client.Finding
+}
+
+// diffReport is the --format json output of findings diff
+type diffReport struct {
+	From *diffSide `json:"from"`
+	To   *diffSide `json:"to"`
+	*snapshot.Diff
+}
+
+// diffRow is a changed finding as shown in the table
+type diffRow struct {
+	CloudAccountID string
+	RuleID         string
+	Change         string
+	Level          string
+	Region         string
+	ObjectID       string
+}
+
+type findingsDiffCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	format string
+}
+
+func newFindingsDiffCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsDiff := &findingsDiffCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsDiffUse,
+		Short:   content.CmdFindingsDiffShort,
+		Long:    content.CmdFindingsDiffLong,
+		Example: content.CmdFindingsDiffExample,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if jsonFormat && !cmd.Flags().Changed(content.CmdFlagFormatLong) {
+				findingsDiff.format = "json"
+			}
+			switch findingsDiff.format {
+			case "table", "json", "markdown":
+			default:
+				return fmt.Errorf(content.ErrorInvalidDiffFormat, findingsDiff.format)
+			}
+
+			if findingsDiff.client == nil {
+				findingsDiff.client = newCoreoClient()
+			}
+
+			return findingsDiff.run(args[0], args[1])
+		},
+	}
+
+	f := cmd.Flags()
+	findingsDiff.addFlags(f)
+	f.StringVarP(&findingsDiff.format, content.CmdFlagFormatLong, "", "table", content.CmdFlagDiffFormatDescription)
+
+	return cmd
+}
+
+// side loads a snapshot file, or queries the findings now or at a time. The
+// filter flags apply to both, a snapshot is filtered in memory.
+func (t *findingsDiffCmd) side(arg string) (*diffSide, error) {
+	filter, err := t.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(arg); err == nil {
+		s, err := snapshot.Load(arg)
+		if err != nil {
+			return nil, err
+		}
+		findings := []*client.Finding{}
+		for _, finding := range s.Findings {
+			if filter.Match(finding) {
+				findings = append(findings, finding)
+			}
+		}
+		return &diffSide{Source: arg, TakenAt: s.TakenAt, findings: findings}, nil
+	}
+
+	at := timeNow().UTC()
+	if arg != diffNow {
+		if at, err = time.Parse(time.RFC3339, arg); err != nil {
+			if at, err = time.Parse("2006-01-02", arg); err != nil {
+				return nil, fmt.Errorf(content.ErrorInvalidDiffSide, arg)
+			}
+		}
+		filter.AsOf = at.UTC().Format(time.RFC3339)
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &diffSide{Source: arg, TakenAt: at.UTC(), findings: findings}, nil
+}
+
+func (t *findingsDiffCmd) run(a, b string) error {
+	from, err := t.side(a)
+	if err != nil {
+		return err
+	}
+	to, err := t.side(b)
+	if err != nil {
+		return err
+	}
+
+	report := &diffReport{From: from, To: to, Diff: snapshot.Compare(from.findings, to.findings)}
+	switch t.format {
+	case "json":
+		fmt.Fprint(t.out, util.PrettyJSON(report))
+	case "markdown":
+		fmt.Fprint(t.out, diffMarkdown(report))
+	default:
+		t.printTable(report)
+	}
+	return nil
+}
+
+// diffRows flattens the groups of a diff, in order
+func diffRows(diff *snapshot.Diff) []*diffRow {
+	rows := make([]*diffRow, 0)
+	for _, g := range diff.Groups {
+		for _, change := range []struct {
+			name     string
+			findings []*client.Finding
+		}{
+			{"New", g.New},
+			{"Resolved", g.Resolved},
+			{"Still open", g.StillOpen},
+		} {
+			for _, finding := range change.findings {
+				rows = append(rows, &diffRow{
+					CloudAccountID: g.CloudAccountID,
+					RuleID:         g.RuleID,
+					Change:         change.name,
+					Level:          finding.Level,
+					Region:         finding.Region,
+					ObjectID:       finding.ObjectID,
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func (t *findingsDiffCmd) printTable(report *diffReport) {
+	fmt.Fprintf(t.out, content.InfoDiffSummary, report.New, report.Resolved, report.StillOpen)
+
+	rows := diffRows(report.Diff)
+	if len(rows) == 0 {
+		return
+	}
+
+	b := make([]interface{}, len(rows))
+	for i := range rows {
+		b[i] = rows[i]
+	}
+
+	fmt.Fprintln(t.out)
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"CloudAccountID", "RuleID", "Change", "Level", "Region", "ObjectID"},
+		map[string]string{
+			"CloudAccountID": "Cloud Account ID",
+			"RuleID":         "Rule ID",
+			"Change":         "Change",
+			"Level":          "Severity",
+			"Region":         "Region",
+			"ObjectID":       "Object ID",
+		},
+		false,
+		verbose)
+}
+
+// markdownEscape keeps a value from breaking a markdown table
+func markdownEscape(value string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(value)
+}
+
+// diffMarkdown renders the diff for pasting into a change record
+func diffMarkdown(report *diffReport) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "# Findings diff")
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "From `%s` (%s) to `%s` (%s).\n\n",
+		report.From.Source, report.From.TakenAt.Format(time.RFC3339), report.To.Source, report.To.TakenAt.Format(time.RFC3339))
+	fmt.Fprintln(buf, "| New | Resolved | Still open |")
+	fmt.Fprintln(buf, "| ---: | ---: | ---: |")
+	fmt.Fprintf(buf, "| %d | %d | %d |\n", report.New, report.Resolved, report.StillOpen)
+
+	account := ""
+	for i, row := range diffRows(report.Diff) {
+		if i == 0 || row.CloudAccountID != account {
+			account = row.CloudAccountID
+			fmt.Fprintf(buf, "\n## Cloud account %s\n\n", markdownEscape(account))
+			fmt.Fprintln(buf, "| Rule | Change | Severity | Region | Object |")
+			fmt.Fprintln(buf, "| --- | --- | --- | --- | --- |")
+		}
+		fmt.Fprintf(buf, "| %s | %s | %s | %s | %s |\n", markdownEscape(row.RuleID), row.Change,
+			markdownEscape(row.Level), markdownEscape(row.Region), markdownEscape(row.ObjectID))
+	}
+	return buf.String()
+}
@@ -0,0 +1,103 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingsSnapshotCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	dir, _ := ioutil.TempDir("", "vss-snapshot")
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "before.json")
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{{RuleID: "rule-1", ObjectID: "o1", Status: "Open"}}}
+	var buf bytes.Buffer
+	cmd := newFindingsSnapshotCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--cloud-id", "c1", "-o", output})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Saved 1 findings to "+output+"\n", buf.String())
+
+	s, err := snapshot.Load(output)
+	assert.Nil(t, err)
+	assert.Equal(t, frc.findings, s.Findings)
+	assert.Equal(t, []string{"c1"}, s.Filter.CloudAccountIDs)
+	assert.Equal(t, "2026-10-18T12:00:00Z", s.TakenAt.Format(time.RFC3339))
+}
+
+func TestFindingsDiffCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	dir, _ := ioutil.TempDir("", "vss-diff")
+	defer os.RemoveAll(dir)
+	before := filepath.Join(dir, "before.json")
+	snapshot.New([]*client.Finding{
+		{CloudAccountID: "c1", RuleID: "rule-1", ObjectID: "o1", Status: "Open", Level: "High", Region: "us-east-1"},
+		{CloudAccountID: "c1", RuleID: "rule-1", ObjectID: "o2", Status: "Open", Level: "High", Region: "us-east-1"},
+	}, client.FindingFilter{}, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)).Save(before)
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{CloudAccountID: "c1", RuleID: "rule-1", ObjectID: "o2", Status: "Open", Level: "High", Region: "us-east-1"},
+		{CloudAccountID: "c2", RuleID: "rule|2", ObjectID: "o3", Status: "Open", Level: "Low", Region: "westus"},
+	}}
+	var buf bytes.Buffer
+	cmd := newFindingsDiffCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--format", "markdown"})
+	assert.Nil(t, cmd.RunE(cmd, []string{before, "now"}))
+	assert.Equal(t, "# Findings diff\n\n"+
+		"From `"+before+"` (2026-10-01T00:00:00Z) to `now` (2026-10-18T12:00:00Z).\n\n"+
+		"| New | Resolved | Still open |\n| ---: | ---: | ---: |\n| 1 | 1 | 1 |\n\n"+
+		"## Cloud account c1\n\n"+
+		"| Rule | Change | Severity | Region | Object |\n| --- | --- | --- | --- | --- |\n"+
+		"| rule-1 | Resolved | High | us-east-1 | o1 |\n"+
+		"| rule-1 | Still open | High | us-east-1 | o2 |\n\n"+
+		"## Cloud account c2\n\n"+
+		"| Rule | Change | Severity | Region | Object |\n| --- | --- | --- | --- | --- |\n"+
+		"| rule\\|2 | New | Low | westus | o3 |\n", buf.String())
+	assert.Equal(t, "", frc.findingFilter.AsOf)
+
+	buf.Reset()
+	cmd = newFindingsDiffCmd(frc, &buf)
+	assert.Nil(t, cmd.RunE(cmd, []string{before, "2026-10-15"}))
+	assert.Equal(t, "2026-10-15T00:00:00Z", frc.findingFilter.AsOf)
+	assert.Contains(t, buf.String(), "New:        1\nResolved:   1\nStill open: 1\n")
+	assert.Contains(t, buf.String(), "Still open")
+
+	buf.Reset()
+	cmd = newFindingsDiffCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--cloud-id", "c2"})
+	assert.Nil(t, cmd.RunE(cmd, []string{before, "now"}))
+	assert.Equal(t, []string{"c2"}, frc.findingFilter.CloudAccountIDs)
+	assert.Contains(t, buf.String(), "New:        2\nResolved:   0\nStill open: 0\n", "the snapshot must be filtered too")
+}
+
+func TestFindingsDiffCmdFailure(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newFindingsDiffCmd(&fakeReleaseClient{}, &buf)
+	assert.EqualError(t, cmd.RunE(cmd, []string{"yesterday", "now"}), `"yesterday" is neither a snapshot file, a time as YYYY-MM-DD or RFC 3339, nor 'now'`)
+
+	cmd = newFindingsDiffCmd(&fakeReleaseClient{}, &buf)
+	cmd.ParseFlags([]string{"--format", "csv"})
+	assert.NotNil(t, cmd.RunE(cmd, []string{"now", "now"}))
+}
@@ -0,0 +1,102 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/sarif"
+	"github.com/spf13/cobra"
+)
+
+// exportFormatSARIF is the only export format so far
+const exportFormatSARIF = "sarif"
+
+type findingsExportCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	format string
+	output string
+}
+
+func newFindingsExportCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsExport := &findingsExportCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsExportUse,
+		Short:   content.CmdFindingsExportShort,
+		Long:    content.CmdFindingsExportLong,
+		Example: content.CmdFindingsExportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.ToLower(findingsExport.format) != exportFormatSARIF {
+				return fmt.Errorf(content.ErrorInvalidExportFormat, findingsExport.format)
+			}
+
+			if findingsExport.client == nil {
+				findingsExport.client = newCoreoClient()
+			}
+
+			return findingsExport.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsExport.addFlags(f)
+	f.StringVarP(&findingsExport.format, content.CmdFlagFormatLong, "", exportFormatSARIF, content.CmdFlagExportFormatDescription)
+	f.StringVarP(&findingsExport.output, content.CmdFlagOutputLong, content.CmdFlagOutputShort, "", content.CmdFlagOutputDescription)
+
+	return cmd
+}
+
+func (t *findingsExportCmd) run() error {
+	filter, err := t.filter()
+	if err != nil {
+		return err
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	log := sarif.FromFindings(findings, version)
+	if t.output == "" {
+		return sarif.Write(t.out, log)
+	}
+
+	file, err := os.Create(t.output)
+	if err != nil {
+		return err
+	}
+	if err := sarif.Write(file, log); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(t.out, content.InfoFindingsExported, len(findings), t.output)
+	return nil
+}
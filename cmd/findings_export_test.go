@@ -0,0 +1,69 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/sarif"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingsExportCmd(t *testing.T) {
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{ID: "f1", RuleID: "rule-1", Level: "High", Status: "Open", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket-1"},
+	}}
+
+	var buf bytes.Buffer
+	cmd := newFindingsExportCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--severity", "high"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, []string{"High"}, frc.findingFilter.Levels)
+
+	log := &sarif.Log{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), log))
+	assert.Equal(t, sarif.Version, log.Version)
+	assert.Equal(t, "rule-1", log.Runs[0].Results[0].RuleID)
+}
+
+func TestFindingsExportCmdOutputFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "vss-export")
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "findings.sarif")
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{{ID: "f1", RuleID: "rule-1"}}}
+	var buf bytes.Buffer
+	cmd := newFindingsExportCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--format", "SARIF", "-o", output})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Exported 1 findings to "+output+"\n", buf.String())
+
+	b, err := ioutil.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"ruleId": "rule-1"`)
+}
+
+func TestFindingsExportCmdInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newFindingsExportCmd(&fakeReleaseClient{}, &buf)
+	cmd.ParseFlags([]string{"--format", "csv"})
+	assert.NotNil(t, cmd.RunE(cmd, nil))
+}
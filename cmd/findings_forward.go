@@ -0,0 +1,206 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/forward"
+	"github.com/spf13/cobra"
+)
+
+// Forward targets
+const (
+	targetSplunk  = "splunk"
+	targetSyslog  = "syslog"
+	targetWebhook = "webhook"
+)
+
+type findingsForwardCmd struct {
+	out    io.Writer
+	client command.Interface
+	sink   forward.Sink
+	findingFilterFlags
+	target     string
+	url        string
+	token      string
+	address    string
+	protocol   string
+	headers    []string
+	batchSize  int
+	checkpoint string
+	full       bool
+}
+
+func newFindingsForwardCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsForward := &findingsForwardCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsForwardUse,
+		Short:   content.CmdFindingsForwardShort,
+		Long:    content.CmdFindingsForwardLong,
+		Example: content.CmdFindingsForwardExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if findingsForward.sink == nil {
+				sink, err := findingsForward.newSink()
+				if err != nil {
+					return err
+				}
+				findingsForward.sink = sink
+			}
+
+			if findingsForward.client == nil {
+				findingsForward.client = newCoreoClient()
+			}
+
+			return findingsForward.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsForward.addFlags(f)
+	f.StringVarP(&findingsForward.target, content.CmdFlagTargetLong, "", "", content.CmdFlagTargetDescription)
+	f.StringVarP(&findingsForward.url, content.CmdFlagURLLong, "", "", content.CmdFlagForwardURLDescription)
+	f.StringVarP(&findingsForward.token, content.CmdFlagHECTokenLong, "", os.Getenv(hecTokenEnvVar), content.CmdFlagHECTokenDescription)
+	f.StringVarP(&findingsForward.address, content.CmdFlagAddressLong, "", "", content.CmdFlagSyslogAddressDescription)
+	f.StringVarP(&findingsForward.protocol, content.CmdFlagProtocolLong, "", forward.SyslogTLS, content.CmdFlagSyslogProtocolDescription)
+	f.StringArrayVarP(&findingsForward.headers, content.CmdFlagHeaderLong, content.CmdFlagHeaderShort, nil, content.CmdFlagWebhookHeaderDescription)
+	f.IntVarP(&findingsForward.batchSize, content.CmdFlagBatchSizeLong, "", 100, content.CmdFlagBatchSizeDescription)
+	f.StringVarP(&findingsForward.checkpoint, content.CmdFlagCheckpointLong, "", "", content.CmdFlagCheckpointDescription)
+	f.BoolVarP(&findingsForward.full, content.CmdFlagFullLong, "", false, content.CmdFlagFullDescription)
+
+	return cmd
+}
+
+// destination names where events go, it keys the default checkpoint
+func (t *findingsForwardCmd) destination() string {
+	if t.target == targetSyslog {
+		return t.protocol + "-" + t.address
+	}
+	return t.url
+}
+
+func (t *findingsForwardCmd) newSink() (forward.Sink, error) {
+	switch t.target {
+	case targetSplunk:
+		if t.url == "" || t.token == "" {
+			return nil, fmt.Errorf(content.ErrorSplunkFlagsRequired)
+		}
+		return forward.NewSplunk(t.url, t.token, t.batchSize), nil
+	case targetWebhook:
+		if t.url == "" {
+			return nil, fmt.Errorf(content.ErrorWebhookURLRequired)
+		}
+		header, err := parseHeaders(t.headers)
+		if err != nil {
+			return nil, err
+		}
+		return forward.NewWebhook(t.url, header, t.batchSize), nil
+	case targetSyslog:
+		if t.address == "" {
+			return nil, fmt.Errorf(content.ErrorSyslogAddressRequired)
+		}
+		return forward.NewSyslog(strings.ToLower(t.protocol), t.address, tlsConfig())
+	default:
+		return nil, fmt.Errorf(content.ErrorInvalidForwardTarget, t.target)
+	}
+}
+
+// checkpointPath is one checkpoint per profile, destination and filter, so
+// that forwarding other findings to the same destination does not report the
+// findings outside the new filter as resolved
+func (t *findingsForwardCmd) checkpointPath(filter client.FindingFilter) string {
+	if t.checkpoint != "" {
+		return t.checkpoint
+	}
+	name := fmt.Sprintf("%s-%s-%s", userProfile, t.target, fileSlug(t.destination()))
+	if key := filterKey(filter); key != "" {
+		name += "-" + key
+	}
+	return filepath.Join(homePath(), content.ForwardCheckpointFolder, name+".json")
+}
+
+// filterKey is a short hash of filter that does not depend on the order of
+// the values, empty when the filter matches everything
+func filterKey(filter client.FindingFilter) string {
+	for _, values := range []*[]string{&filter.CloudAccountIDs, &filter.RuleIDs, &filter.Levels, &filter.Providers, &filter.Regions, &filter.Statuses} {
+		*values = append([]string(nil), *values...)
+		sort.Strings(*values)
+	}
+	key, _ := json.Marshal(filter)
+	if string(key) == "{}" {
+		return ""
+	}
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func (t *findingsForwardCmd) run() error {
+	defer t.sink.Close()
+
+	filter, err := t.filter()
+	if err != nil {
+		return err
+	}
+
+	checkpoint := forward.NewCheckpoint(nil)
+	if !t.full {
+		if checkpoint, err = forward.LoadCheckpoint(t.checkpointPath(filter)); err != nil {
+			return err
+		}
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	events := checkpoint.Changes(findings, timeNow().UTC())
+	if len(events) == 0 {
+		fmt.Fprintln(t.out, content.InfoNothingToForward)
+		return nil
+	}
+
+	// The checkpoint only moves once every event was delivered, a failed run
+	// sends the same events again next time.
+	if err := t.sink.Send(commandCtx, events); err != nil {
+		return err
+	}
+	if err := forward.NewCheckpoint(findings).Save(t.checkpointPath(filter)); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, event := range events {
+		counts[event.Action]++
+	}
+	fmt.Fprintf(t.out, content.InfoFindingsForwarded, len(events), counts[forward.ActionNew],
+		counts[forward.ActionChanged], counts[forward.ActionResolved], t.target)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/forward"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSink struct {
+	events []*forward.Event
+	err    error
+	closed bool
+}
+
+func (s *fakeSink) Send(ctx context.Context, events []*forward.Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestFindingsForwardCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	dir, _ := ioutil.TempDir("", "vss-forward")
+	defer os.RemoveAll(dir)
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Open"},
+		{RuleID: "rule-1", ObjectID: "o2", Level: "High", Status: "Open"},
+	}}
+	run := func(sink *fakeSink, full bool) (string, error) {
+		var buf bytes.Buffer
+		findingsForward := &findingsForwardCmd{
+			out:        &buf,
+			client:     frc,
+			sink:       sink,
+			target:     targetWebhook,
+			checkpoint: filepath.Join(dir, "checkpoint.json"),
+			full:       full,
+		}
+		err := findingsForward.run()
+		assert.True(t, sink.closed)
+		return buf.String(), err
+	}
+
+	sink := &fakeSink{}
+	out, err := run(sink, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "Forwarded 2 events (2 new, 0 changed, 0 resolved) to webhook\n", out)
+	assert.Equal(t, 2, len(sink.events))
+
+	sink = &fakeSink{}
+	out, err = run(sink, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "No finding changed since the last forward.\n", out)
+	assert.Empty(t, sink.events)
+
+	frc.findings = []*client.Finding{
+		{RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Suppressed"},
+		{RuleID: "rule-2", ObjectID: "o3", Level: "Low", Status: "Open"},
+	}
+	out, err = run(&fakeSink{err: fmt.Errorf("connection refused")}, false)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, "", out)
+
+	sink = &fakeSink{}
+	out, err = run(sink, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "Forwarded 3 events (1 new, 1 changed, 1 resolved) to webhook\n", out, "a failed send does not move the checkpoint")
+	assert.Equal(t, "Resolved", sink.events[1].Finding.Status)
+
+	sink = &fakeSink{}
+	out, err = run(sink, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "Forwarded 2 events (2 new, 0 changed, 0 resolved) to webhook\n", out)
+}
+
+func TestFindingsForwardCmdSinks(t *testing.T) {
+	tests := []struct {
+		desc            string
+		findingsForward *findingsForwardCmd
+		err             string
+	}{
+		{desc: "no target", findingsForward: &findingsForwardCmd{}, err: `Unsupported target ""`},
+		{desc: "splunk without token", findingsForward: &findingsForwardCmd{target: targetSplunk, url: "https://splunk"}, err: "Forwarding to Splunk"},
+		{desc: "splunk", findingsForward: &findingsForwardCmd{target: targetSplunk, url: "https://splunk", token: "t"}},
+		{desc: "webhook without url", findingsForward: &findingsForwardCmd{target: targetWebhook}, err: "Forwarding to a webhook"},
+		{desc: "webhook bad header", findingsForward: &findingsForwardCmd{target: targetWebhook, url: "https://hook", headers: []string{"bad"}}, err: "bad"},
+		{desc: "syslog without address", findingsForward: &findingsForwardCmd{target: targetSyslog}, err: "Forwarding to syslog"},
+		{desc: "syslog bad protocol", findingsForward: &findingsForwardCmd{target: targetSyslog, address: "siem:514", protocol: "http"}, err: "unsupported syslog protocol"},
+		{desc: "syslog", findingsForward: &findingsForwardCmd{target: targetSyslog, address: "siem:514", protocol: "UDP"}},
+	}
+
+	for _, tt := range tests {
+		sink, err := tt.findingsForward.newSink()
+		if tt.err == "" {
+			assert.Nil(t, err, tt.desc)
+			assert.NotNil(t, sink, tt.desc)
+			continue
+		}
+		if assert.NotNil(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.err, tt.desc)
+		}
+	}
+}
+
+func TestFindingsForwardCheckpointPath(t *testing.T) {
+	defer func(profile string) { userProfile = profile }(userProfile)
+	userProfile = "default"
+
+	findingsForward := &findingsForwardCmd{target: targetSyslog, protocol: "tls", address: "siem.example.com:6514"}
+	assert.Equal(t, filepath.Join(homePath(), "forward", "default-syslog-"+fileSlug("tls-siem.example.com:6514")+".json"),
+		findingsForward.checkpointPath(client.FindingFilter{}))
+
+	high := findingsForward.checkpointPath(client.FindingFilter{Levels: []string{"High"}})
+	assert.Equal(t, filepath.Join(homePath(), "forward", "default-syslog-"+fileSlug("tls-siem.example.com:6514")+"-"+filterKey(client.FindingFilter{Levels: []string{"High"}})+".json"), high)
+	assert.NotEqual(t, high, findingsForward.checkpointPath(client.FindingFilter{Levels: []string{"High", "Medium"}}))
+	assert.NotEqual(t, high, findingsForward.checkpointPath(client.FindingFilter{CloudAccountIDs: []string{"c1"}}))
+
+	ids := []string{"c2", "c1"}
+	assert.Equal(t, findingsForward.checkpointPath(client.FindingFilter{CloudAccountIDs: []string{"c1", "c2"}}),
+		findingsForward.checkpointPath(client.FindingFilter{CloudAccountIDs: ids}), "the order of the values does not matter")
+	assert.Equal(t, []string{"c2", "c1"}, ids)
+
+	findingsForward.checkpoint = "state.json"
+	assert.Equal(t, "state.json", findingsForward.checkpointPath(client.FindingFilter{Levels: []string{"High"}}))
+}
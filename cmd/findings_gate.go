@@ -0,0 +1,175 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/baseline"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// severityRank orders the severities a gate can fail on
+var severityRank = map[string]int{"Low": 1, "Medium": 2, "High": 3}
+
+// gateRank is the rank of level regardless of case. A level the gate does not
+// know ranks above High so that it always fails the gate.
+func gateRank(level string) int {
+	for severity, rank := range severityRank {
+		if strings.EqualFold(level, severity) {
+			return rank
+		}
+	}
+	return len(severityRank) + 1
+}
+
+// gateFailedError is returned when new findings reach the gate threshold,
+// it exits with exitGateFailed
+type gateFailedError struct {
+	count     int
+	threshold string
+}
+
+func (e *gateFailedError) Error() string {
+	return fmt.Sprintf(content.ErrorGateFailed, e.count, e.threshold)
+}
+
+// gateSummary is the --json output of findings gate
+type gateSummary struct {
+	Threshold string            `json:"threshold"`
+	Passed    bool              `json:"passed"`
+	New       []*client.Finding `json:"new"`
+	Failing   []*client.Finding `json:"failing"`
+	Fixed     []*baseline.Entry `json:"fixed"`
+	Accepted  int               `json:"accepted"`
+}
+
+type findingsGateCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	file   string
+	failOn string
+}
+
+func newFindingsGateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsGate := &findingsGateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsGateUse,
+		Short:   content.CmdFindingsGateShort,
+		Long:    content.CmdFindingsGateLong,
+		Example: content.CmdFindingsGateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if findingsGate.client == nil {
+				findingsGate.client = newCoreoClient()
+			}
+
+			return findingsGate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsGate.addFlags(f)
+	f.StringVarP(&findingsGate.file, content.CmdFlagBaselineLong, "", defaultBaselineFile, content.CmdFlagBaselineDescription)
+	f.StringVarP(&findingsGate.failOn, content.CmdFlagFailOnLong, "", "High", content.CmdFlagFailOnDescription)
+
+	return cmd
+}
+
+func (t *findingsGateCmd) run() error {
+	threshold, err := util.NormalizeValues([]string{t.failOn}, []string{"High", "Medium", "Low"}, content.ErrorInvalidSeverity)
+	if err != nil {
+		return err
+	}
+
+	b, err := baseline.Load(t.file)
+	if err != nil {
+		return err
+	}
+
+	filter, err := t.openFilter()
+	if err != nil {
+		return err
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	comparison := b.Compare(findings)
+	summary := &gateSummary{
+		Threshold: threshold[0],
+		New:       comparison.New,
+		Failing:   []*client.Finding{},
+		Fixed:     comparison.FixedWithin(filter),
+		Accepted:  len(comparison.Accepted),
+	}
+	for _, finding := range comparison.New {
+		if gateRank(finding.Level) >= severityRank[summary.Threshold] {
+			summary.Failing = append(summary.Failing, finding)
+		}
+	}
+	summary.Passed = len(summary.Failing) == 0
+
+	t.print(summary)
+
+	if !summary.Passed {
+		return &gateFailedError{count: len(summary.Failing), threshold: summary.Threshold}
+	}
+	return nil
+}
+
+func (t *findingsGateCmd) print(summary *gateSummary) {
+	if jsonFormat {
+		fmt.Fprint(t.out, util.PrettyJSON(summary))
+		return
+	}
+
+	fmt.Fprintf(t.out, content.InfoGateSummary, len(summary.New), len(summary.Failing), summary.Threshold, len(summary.Fixed), summary.Accepted)
+	if len(summary.Failing) == 0 {
+		return
+	}
+
+	b := make([]interface{}, len(summary.Failing))
+	for i := range summary.Failing {
+		b[i] = summary.Failing[i]
+	}
+
+	fmt.Fprintln(t.out)
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"RuleID", "Level", "CloudAccountID", "Region", "ObjectID"},
+		map[string]string{
+			"RuleID":         "Rule ID",
+			"Level":          "Severity",
+			"CloudAccountID": "Cloud Account ID",
+			"Region":         "Region",
+			"ObjectID":       "Object ID",
+		},
+		false,
+		verbose)
+}
@@ -0,0 +1,178 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/baseline"
+	"github.com/stretchr/testify/assert"
+)
+
+func baselineFile(t *testing.T, findings ...*client.Finding) (string, func()) {
+	dir, _ := ioutil.TempDir("", "vss-gate")
+	path := filepath.Join(dir, "baseline.json")
+	if findings != nil {
+		assert.Nil(t, baseline.New(findings).Save(path))
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestFindingsGateCmd(t *testing.T) {
+	accepted := &client.Finding{RuleID: "rule-1", Level: "High", CloudAccountID: "c1", ObjectID: "o1"}
+	fixed := &client.Finding{RuleID: "rule-2", Level: "High", CloudAccountID: "c1", ObjectID: "o2"}
+	path, cleanup := baselineFile(t, accepted, fixed)
+	defer cleanup()
+
+	tests := []struct {
+		desc     string
+		flags    []string
+		findings []*client.Finding
+		failing  int
+	}{
+		{desc: "only accepted", findings: []*client.Finding{accepted}},
+		{desc: "new below threshold", findings: []*client.Finding{accepted, {RuleID: "rule-3", Level: "Medium", ObjectID: "o3"}}},
+		{desc: "new at threshold", flags: []string{"--fail-on", "medium"}, findings: []*client.Finding{accepted, {RuleID: "rule-3", Level: "Medium", ObjectID: "o3"}}, failing: 1},
+		{desc: "new high", findings: []*client.Finding{{RuleID: "rule-3", Level: "High", ObjectID: "o3"}, {RuleID: "rule-4", Level: "High", ObjectID: "o4"}}, failing: 2},
+		{desc: "mixed case level", findings: []*client.Finding{{RuleID: "rule-3", Level: "HIGH", ObjectID: "o3"}, {RuleID: "rule-4", Level: "high", ObjectID: "o4"}}, failing: 2},
+		{desc: "mixed case below threshold", findings: []*client.Finding{{RuleID: "rule-3", Level: "medium", ObjectID: "o3"}}},
+		{desc: "unknown level", flags: []string{"--fail-on", "high"}, findings: []*client.Finding{{RuleID: "rule-3", Level: "Critical", ObjectID: "o3"}, {RuleID: "rule-4", ObjectID: "o4"}}, failing: 2},
+	}
+
+	for _, tt := range tests {
+		frc := &fakeReleaseClient{findings: tt.findings}
+		var buf bytes.Buffer
+		cmd := newFindingsGateCmd(frc, &buf)
+		cmd.ParseFlags(append([]string{"--baseline", path}, tt.flags...))
+		err := cmd.RunE(cmd, nil)
+		assert.Equal(t, []string{"Open"}, frc.findingFilter.Statuses, tt.desc)
+
+		if tt.failing == 0 {
+			assert.Nil(t, err, tt.desc)
+			continue
+		}
+		assert.IsType(t, &gateFailedError{}, err, tt.desc)
+		assert.Equal(t, tt.failing, err.(*gateFailedError).count, tt.desc)
+		assert.Equal(t, exitGateFailed, exitCode(err), tt.desc)
+		assert.Contains(t, buf.String(), "Rule ID", tt.desc)
+	}
+}
+
+func TestFindingsGateCmdSummary(t *testing.T) {
+	accepted := &client.Finding{RuleID: "rule-1", Level: "High", ObjectID: "o1"}
+	path, cleanup := baselineFile(t, accepted, &client.Finding{RuleID: "rule-2", ObjectID: "o2"})
+	defer cleanup()
+
+	var buf bytes.Buffer
+	cmd := newFindingsGateCmd(&fakeReleaseClient{findings: []*client.Finding{accepted, {RuleID: "rule-3", Level: "Low", ObjectID: "o3"}}}, &buf)
+	cmd.ParseFlags([]string{"--baseline", path})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "New:      1 (0 at or above High)\nFixed:    1\nAccepted: 1\n", buf.String())
+}
+
+func TestFindingsGateCmdFixedWithinFilter(t *testing.T) {
+	path, cleanup := baselineFile(t,
+		&client.Finding{RuleID: "rule-1", Level: "High", CloudAccountID: "c1", ObjectID: "o1"},
+		&client.Finding{RuleID: "rule-2", Level: "Low", CloudAccountID: "c1", ObjectID: "o2"},
+		&client.Finding{RuleID: "rule-3", Level: "High", CloudAccountID: "c2", ObjectID: "o3"})
+	defer cleanup()
+
+	var buf bytes.Buffer
+	cmd := newFindingsGateCmd(&fakeReleaseClient{}, &buf)
+	cmd.ParseFlags([]string{"--baseline", path, "--severity", "high", "--cloud-id", "c1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "New:      0 (0 at or above High)\nFixed:    1\nAccepted: 0\n", buf.String())
+}
+
+func TestFindingsGateCmdMissingBaseline(t *testing.T) {
+	path, cleanup := baselineFile(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	cmd := newFindingsGateCmd(&fakeReleaseClient{}, &buf)
+	cmd.ParseFlags([]string{"--baseline", path})
+	err := cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, exitError, exitCode(err))
+}
+
+func TestBaselineCreateAndUpdateCmd(t *testing.T) {
+	path, cleanup := baselineFile(t)
+	defer cleanup()
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{{RuleID: "rule-1", ObjectID: "o1"}, {RuleID: "rule-2", ObjectID: "o2"}}}
+	var buf bytes.Buffer
+	cmd := newBaselineCreateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--baseline", path})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Created baseline "+path+" accepting 2 findings\n", buf.String())
+
+	assert.NotNil(t, cmd.RunE(cmd, nil), "create must not overwrite a baseline")
+
+	frc.findings = []*client.Finding{{RuleID: "rule-2", ObjectID: "o2"}, {RuleID: "rule-3", ObjectID: "o3"}, {RuleID: "rule-4", ObjectID: "o4"}}
+	buf.Reset()
+	cmd = newBaselineUpdateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--baseline", path})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Updated baseline "+path+": 2 new findings accepted, 1 fixed findings removed, 3 findings accepted in total\n", buf.String())
+
+	b, err := baseline.Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(b.Findings))
+	assert.Equal(t, "rule-2", b.Findings[0].RuleID)
+}
+
+func TestBaselineUpdateCmdKeepsEntriesOutsideFilter(t *testing.T) {
+	path, cleanup := baselineFile(t,
+		&client.Finding{RuleID: "rule-1", Level: "High", CloudAccountID: "c1", ObjectID: "o1"},
+		&client.Finding{RuleID: "rule-2", Level: "High", CloudAccountID: "c1", ObjectID: "o2"},
+		&client.Finding{RuleID: "rule-3", Level: "High", CloudAccountID: "c2", ObjectID: "o3"},
+		&client.Finding{RuleID: "rule-4", Level: "Low", CloudAccountID: "c1", ObjectID: "o4"})
+	defer cleanup()
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{RuleID: "rule-1", Level: "High", CloudAccountID: "c1", ObjectID: "o1"},
+		{RuleID: "rule-5", Level: "High", CloudAccountID: "c1", ObjectID: "o5"},
+	}}
+	var buf bytes.Buffer
+	cmd := newBaselineUpdateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--baseline", path, "--cloud-id", "c1", "--severity", "high"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Updated baseline "+path+": 1 new findings accepted, 1 fixed findings removed, 4 findings accepted in total\n", buf.String())
+
+	b, err := baseline.Load(path)
+	assert.Nil(t, err)
+	objects := make([]string, len(b.Findings))
+	for i, entry := range b.Findings {
+		objects[i] = entry.ObjectID
+	}
+	assert.Equal(t, []string{"o1", "o3", "o4", "o5"}, objects, "o2 is fixed, o3 and o4 are outside the filter")
+
+	buf.Reset()
+	frc.findings = []*client.Finding{
+		{RuleID: "rule-1", Level: "High", CloudAccountID: "c1", ObjectID: "o1"},
+		{RuleID: "rule-3", Level: "High", CloudAccountID: "c2", ObjectID: "o3"},
+		{RuleID: "rule-4", Level: "Low", CloudAccountID: "c1", ObjectID: "o4"},
+		{RuleID: "rule-5", Level: "High", CloudAccountID: "c1", ObjectID: "o5"},
+	}
+	gate := newFindingsGateCmd(frc, &buf)
+	gate.ParseFlags([]string{"--baseline", path})
+	assert.Nil(t, gate.RunE(gate, nil), "the findings accepted outside the filter still pass the gate")
+}
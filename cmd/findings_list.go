@@ -0,0 +1,151 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// findingFilterFlags are the filter flags shared by the findings commands
+type findingFilterFlags struct {
+	cloudIDs   []string
+	ruleIDs    []string
+	severities []string
+	providers  []string
+	regions    []string
+	statuses   []string
+}
+
+func (f *findingFilterFlags) addFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&f.cloudIDs, content.CmdFlagFindingCloudIDLong, nil, content.CmdFlagFindingCloudIDDescription)
+	flags.StringSliceVar(&f.ruleIDs, content.CmdFlagRuleIDLong, nil, content.CmdFlagRuleIDDescription)
+	cobra.MarkFlagCustom(flags, content.CmdFlagRuleIDLong, content.RuleIDCompletionFunc)
+	flags.StringSliceVar(&f.severities, content.CmdFlagSeverityLong, nil, content.CmdFlagSeverityDescription)
+	flags.StringSliceVar(&f.providers, content.CmdFlagFindingProviderLong, nil, content.CmdFlagFindingProviderDescription)
+	flags.StringSliceVar(&f.regions, content.CmdFlagRegionLong, nil, content.CmdFlagRegionDescription)
+	flags.StringSliceVar(&f.statuses, content.CmdFlagStatusLong, nil, content.CmdFlagStatusDescription)
+}
+
+// filter validates the flags and returns them in the casing the API expects
+func (f *findingFilterFlags) filter() (client.FindingFilter, error) {
+	levels, err := util.NormalizeValues(f.severities, []string{"High", "Medium", "Low"}, content.ErrorInvalidSeverity)
+	if err != nil {
+		return client.FindingFilter{}, err
+	}
+	statuses, err := util.NormalizeValues(f.statuses, []string{"Open", "Resolved", "Suppressed"}, content.ErrorInvalidFindingStatus)
+	if err != nil {
+		return client.FindingFilter{}, err
+	}
+	providers, err := util.NormalizeValues(f.providers, []string{"AWS", "Azure"}, content.ErrorInvalidFindingProvider)
+	if err != nil {
+		return client.FindingFilter{}, err
+	}
+
+	return client.FindingFilter{
+		CloudAccountIDs: f.cloudIDs,
+		RuleIDs:         f.ruleIDs,
+		Levels:          levels,
+		Providers:       providers,
+		Regions:         f.regions,
+		Statuses:        statuses,
+	}, nil
+}
+
+type findingsListCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	limit int
+}
+
+func newFindingsListCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsList := &findingsListCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdListUse,
+		Short:   content.CmdFindingsListShort,
+		Long:    content.CmdFindingsListLong,
+		Example: content.CmdFindingsListExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if findingsList.limit < 0 {
+				return errors.New(content.ErrorInvalidLimit)
+			}
+
+			if findingsList.client == nil {
+				findingsList.client = newCoreoClient()
+			}
+
+			return findingsList.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsList.addFlags(f)
+	f.IntVarP(&findingsList.limit, content.CmdFlagLimitLong, "", 0, content.CmdFlagLimitDescription)
+
+	return cmd
+}
+
+func (t *findingsListCmd) run() error {
+	filter, err := t.filter()
+	if err != nil {
+		return err
+	}
+
+	findings, err := t.client.ListFindings(commandCtx, filter, t.limit)
+	if err != nil {
+		return err
+	}
+
+	if len(findings) == 0 && !jsonFormat {
+		fmt.Fprintln(t.out, content.InfoNoFindings)
+		return nil
+	}
+
+	b := make([]interface{}, len(findings))
+	for i := range findings {
+		b[i] = findings[i]
+	}
+
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"RuleID", "Level", "Status", "Provider", "CloudAccountID", "Region", "ObjectID"},
+		map[string]string{
+			"RuleID":         "Rule ID",
+			"Level":          "Severity",
+			"Status":         "Status",
+			"Provider":       "Provider",
+			"CloudAccountID": "Cloud Account ID",
+			"Region":         "Region",
+			"ObjectID":       "Object ID",
+		},
+		jsonFormat,
+		verbose)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingsListCmd(t *testing.T) {
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{ID: "f1", RuleID: "rule-1", Level: "High", Status: "Open", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket-1"},
+		{ID: "f2", RuleID: "rule-2", Level: "Low", Status: "Open", Provider: "AWS", CloudAccountID: "c1", Region: "us-west-2", ObjectID: "bucket-2"},
+	}}
+
+	var buf bytes.Buffer
+	cmd := newFindingsListCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--severity", "high,LOW", "--status", "open", "--provider", "aws", "--cloud-id", "c1", "--region", "us-east-1", "--region", "us-west-2", "--rule-id", "rule-1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	assert.Equal(t, client.FindingFilter{
+		CloudAccountIDs: []string{"c1"},
+		RuleIDs:         []string{"rule-1"},
+		Levels:          []string{"High", "Low"},
+		Providers:       []string{"AWS"},
+		Regions:         []string{"us-east-1", "us-west-2"},
+		Statuses:        []string{"Open"},
+	}, frc.findingFilter)
+	assert.Contains(t, buf.String(), "Severity")
+	assert.Contains(t, buf.String(), "bucket-2")
+}
+
+func TestFindingsListCmdLimit(t *testing.T) {
+	frc := &fakeReleaseClient{findings: []*client.Finding{{ObjectID: "bucket-1"}, {ObjectID: "bucket-2"}}}
+
+	var buf bytes.Buffer
+	cmd := newFindingsListCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--limit", "1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, buf.String(), "bucket-1")
+	assert.NotContains(t, buf.String(), "bucket-2")
+}
+
+func TestFindingsListCmdEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newFindingsListCmd(&fakeReleaseClient{}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, content.InfoNoFindings+"\n", buf.String())
+}
+
+func TestFindingsListCmdFailure(t *testing.T) {
+	tests := []struct {
+		desc  string
+		flags []string
+		err   error
+	}{
+		{desc: "invalid severity", flags: []string{"--severity", "critical"}},
+		{desc: "invalid status", flags: []string{"--status", "closed"}},
+		{desc: "invalid provider", flags: []string{"--provider", "gcp"}},
+		{desc: "negative limit", flags: []string{"--limit", "-1"}},
+		{desc: "api error", err: errors.New("Error")},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newFindingsListCmd(&fakeReleaseClient{err: tt.err}, &buf)
+		cmd.ParseFlags(tt.flags)
+		assert.NotNil(t, cmd.RunE(cmd, nil), tt.desc)
+	}
+}
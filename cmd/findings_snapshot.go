@@ -0,0 +1,83 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+type findingsSnapshotCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	output string
+}
+
+func newFindingsSnapshotCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsSnapshot := &findingsSnapshotCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsSnapshotUse,
+		Short:   content.CmdFindingsSnapshotShort,
+		Long:    content.CmdFindingsSnapshotLong,
+		Example: content.CmdFindingsSnapshotExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if findingsSnapshot.client == nil {
+				findingsSnapshot.client = newCoreoClient()
+			}
+
+			return findingsSnapshot.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsSnapshot.addFlags(f)
+	f.StringVarP(&findingsSnapshot.output, content.CmdFlagOutputLong, content.CmdFlagOutputShort, "", content.CmdFlagSnapshotOutputDescription)
+
+	return cmd
+}
+
+func (t *findingsSnapshotCmd) run() error {
+	filter, err := t.filter()
+	if err != nil {
+		return err
+	}
+
+	takenAt := timeNow().UTC()
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	output := t.output
+	if output == "" {
+		output = fmt.Sprintf(content.DefaultSnapshotFile, takenAt.Format("20060102T150405Z"))
+	}
+	if err := snapshot.New(findings, filter, takenAt).Save(output); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(t.out, content.InfoSnapshotSaved, len(findings), output)
+	return nil
+}
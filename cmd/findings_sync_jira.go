@@ -0,0 +1,132 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/jira"
+	"github.com/spf13/cobra"
+)
+
+type findingsSyncJiraCmd struct {
+	out    io.Writer
+	client command.Interface
+	findingFilterFlags
+	mapping   string
+	jiraUser  string
+	jiraToken string
+	dryRun    bool
+}
+
+func newFindingsSyncJiraCmd(client command.Interface, out io.Writer) *cobra.Command {
+	findingsSyncJira := &findingsSyncJiraCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdFindingsSyncJiraUse,
+		Short:   content.CmdFindingsSyncJiraShort,
+		Long:    content.CmdFindingsSyncJiraLong,
+		Example: content.CmdFindingsSyncJiraExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if findingsSyncJira.client == nil {
+				findingsSyncJira.client = newCoreoClient()
+			}
+
+			return findingsSyncJira.run()
+		},
+	}
+
+	f := cmd.Flags()
+	findingsSyncJira.addFlags(f)
+	f.StringVarP(&findingsSyncJira.mapping, content.CmdFlagMappingLong, "", content.DefaultJiraMappingFile, content.CmdFlagMappingDescription)
+	f.StringVarP(&findingsSyncJira.jiraUser, content.CmdFlagJiraUserLong, "", os.Getenv(jiraUserEnvVar), content.CmdFlagJiraUserDescription)
+	f.StringVarP(&findingsSyncJira.jiraToken, content.CmdFlagJiraTokenLong, "", os.Getenv(jiraTokenEnvVar), content.CmdFlagJiraTokenDescription)
+	f.BoolVarP(&findingsSyncJira.dryRun, content.CmdFlagDryRunLong, "", false, content.CmdFlagJiraDryRunDescription)
+
+	return cmd
+}
+
+func (t *findingsSyncJiraCmd) run() error {
+	mapping, err := jira.LoadMapping(t.mapping)
+	if err != nil {
+		return err
+	}
+
+	filter, err := t.filter()
+	if err != nil {
+		return err
+	}
+	findings, err := t.client.ListFindings(commandCtx, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	jiraClient := jira.NewClient(mapping.URL, t.jiraUser, t.jiraToken)
+	issues, err := jiraClient.Issues(commandCtx, mapping)
+	if err != nil {
+		return err
+	}
+
+	actions := jira.Plan(findings, issues)
+	if len(actions) == 0 && !jsonFormat {
+		fmt.Fprintln(t.out, content.InfoJiraInSync)
+		return nil
+	}
+
+	if t.dryRun {
+		if !jsonFormat {
+			fmt.Fprintln(t.out, content.InfoJiraDryRun)
+		}
+		t.print(actions)
+		return nil
+	}
+
+	// Actions done before a failure are still reported, the next run picks
+	// up the rest since issues are found again by finding ID.
+	done, err := jira.Apply(commandCtx, jiraClient, mapping, actions)
+	t.print(actions[:done])
+	return err
+}
+
+func (t *findingsSyncJiraCmd) print(actions []*jira.Action) {
+	b := make([]interface{}, len(actions))
+	for i := range actions {
+		b[i] = actions[i]
+	}
+
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"Action", "IssueKey", "FindingID", "RuleID", "Level", "ObjectID"},
+		map[string]string{
+			"Action":    "Action",
+			"IssueKey":  "Issue",
+			"FindingID": "Finding ID",
+			"RuleID":    "Rule ID",
+			"Level":     "Severity",
+			"ObjectID":  "Object ID",
+		},
+		jsonFormat,
+		verbose)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func jiraMapping(t *testing.T, url string) (string, func()) {
+	dir, err := ioutil.TempDir("", "vss-jira")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "vss-jira.yaml")
+	ioutil.WriteFile(path, []byte(fmt.Sprintf(`url: %s
+project: SEC
+issueType: Bug
+findingIdField: customfield_10042
+resolveTransition: Done
+`, url)), 0600)
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestFindingsSyncJiraCmd(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			fmt.Fprint(w, `{"total":1,"issues":[{"key":"SEC-1","fields":{"customfield_10042":"f2","status":{"name":"Done","statusCategory":{"key":"done"}}}}]}`)
+		case "/rest/api/2/issue":
+			fmt.Fprint(w, `{"key":"SEC-2"}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	path, cleanup := jiraMapping(t, server.URL)
+	defer cleanup()
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{
+		{ID: "f1", RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Open"},
+		{ID: "f2", RuleID: "rule-1", ObjectID: "o2", Level: "High", Status: "Open"},
+	}}
+	var buf bytes.Buffer
+	cmd := newFindingsSyncJiraCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--mapping", path, "--jira-token", "pat", "--severity", "high", "--dry-run"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, []string{"High"}, frc.findingFilter.Levels)
+	assert.Equal(t, []string{"POST /rest/api/2/search"}, requests, "a dry run only reads")
+	assert.Contains(t, buf.String(), "Dry run, Jira was not changed.")
+	assert.Contains(t, buf.String(), "create")
+	assert.Contains(t, buf.String(), "SEC-1")
+
+	requests = nil
+	buf.Reset()
+	cmd = newFindingsSyncJiraCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--mapping", path})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, []string{"POST /rest/api/2/search", "POST /rest/api/2/issue", "POST /rest/api/2/issue/SEC-1/comment"}, requests)
+	assert.Contains(t, buf.String(), "SEC-2")
+	assert.NotContains(t, buf.String(), "Dry run")
+}
+
+func TestFindingsSyncJiraCmdInSync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":0,"issues":[]}`)
+	}))
+	defer server.Close()
+	path, cleanup := jiraMapping(t, server.URL)
+	defer cleanup()
+
+	frc := &fakeReleaseClient{findings: []*client.Finding{{ID: "f1", Status: "Resolved"}}}
+	var buf bytes.Buffer
+	cmd := newFindingsSyncJiraCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--mapping", path})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Jira is in step with the findings, nothing to do.\n", buf.String())
+
+	cmd = newFindingsSyncJiraCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--mapping", filepath.Join(filepath.Dir(path), "missing.yaml")})
+	assert.NotNil(t, cmd.RunE(cmd, nil))
+}
@@ -0,0 +1,73 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+)
+
+// transportConfig merges the network flags with the settings of the profile
+func transportConfig() client.TransportConfig {
+	cfg := client.TransportConfig{
+		Proxy:      util.CheckProfileFlag(proxy, userProfile, content.Proxy),
+		CABundle:   util.CheckProfileFlag(caBundle, userProfile, content.CABundle),
+		ClientCert: util.CheckProfileFlag(clientCert, userProfile, content.ClientCert),
+		ClientKey:  util.CheckProfileFlag(clientKey, userProfile, content.ClientKey),
+		Insecure:   insecure,
+	}
+	if !cfg.Insecure {
+		cfg.Insecure, _ = strconv.ParseBool(util.CheckProfileFlag("", userProfile, content.Insecure))
+	}
+	return cfg
+}
+
+// setupTransport replaces the default transport when a proxy, CA bundle,
+// client certificate or insecure mode is configured. The API client, the CSP
+// token exchange, the AWS SDK, the Azure SDK and its token refreshes and the
+// Azure webhook posts all send through the default transport, so this is the
+// one place that covers every request the CLI makes.
+func setupTransport() error {
+	cfg := transportConfig()
+	if cfg.IsZero() {
+		return nil
+	}
+
+	transport, err := client.NewTransport(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.Insecure {
+		fmt.Fprint(os.Stderr, content.InfoInsecure)
+	}
+	http.DefaultTransport = transport
+	return nil
+}
+
+// tlsConfig is the TLS configuration of the default transport, for
+// connections that do not go through HTTP such as syslog over TLS
+func tlsConfig() *tls.Config {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		return transport.TLSClientConfig.Clone()
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Notification target types
+const (
+	notificationWebhook = "Webhook"
+	notificationSlack   = "Slack"
+	notificationEmail   = "Email"
+)
+
+func newNotificationsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               content.CmdNotificationsUse,
+		Short:             content.CmdNotificationsShort,
+		Long:              content.CmdNotificationsLong,
+		PersistentPreRunE: setupCoreoConfig,
+	}
+
+	cmd.AddCommand(newNotificationsListCmd(nil, out))
+	cmd.AddCommand(newNotificationsCreateCmd(nil, out))
+	cmd.AddCommand(newNotificationsUpdateCmd(nil, out))
+	cmd.AddCommand(newNotificationsDeleteCmd(nil, out))
+	cmd.AddCommand(newNotificationsTestCmd(nil, out))
+
+	return cmd
+}
+
+// notificationFlags are the settings of a notification target shared by
+// create and update
+type notificationFlags struct {
+	flags      *pflag.FlagSet
+	name       string
+	kind       string
+	url        string
+	emails     []string
+	severities []string
+	cloudIDs   []string
+	enabled    bool
+}
+
+func (n *notificationFlags) addFlags(flags *pflag.FlagSet) {
+	n.flags = flags
+	flags.StringVarP(&n.name, content.CmdFlagNameLong, content.CmdFlagNameShort, "", content.CmdFlagNotificationNameDescription)
+	flags.StringVarP(&n.kind, content.CmdFlagNotificationTypeLong, "", "", content.CmdFlagNotificationTypeDescription)
+	flags.StringVarP(&n.url, content.CmdFlagURLLong, "", "", content.CmdFlagNotificationURLDescription)
+	flags.StringSliceVar(&n.emails, content.CmdFlagEmail, nil, content.CmdFlagNotificationEmailDescription)
+	flags.StringSliceVar(&n.severities, content.CmdFlagSeverityLong, nil, content.CmdFlagNotificationSeverityDescription)
+	flags.StringSliceVar(&n.cloudIDs, content.CmdFlagFindingCloudIDLong, nil, content.CmdFlagNotificationCloudIDDescription)
+	flags.BoolVarP(&n.enabled, content.CmdFlagEnabledLong, "", true, content.CmdFlagEnabledDescription)
+}
+
+// apply sets the fields of target whose flags were given, and checks that
+// the result is a complete target
+func (n *notificationFlags) apply(target *client.NotificationTarget) error {
+	changed := n.flags.Changed
+	if changed(content.CmdFlagNameLong) {
+		target.Name = n.name
+	}
+	if changed(content.CmdFlagNotificationTypeLong) {
+		kinds, err := util.NormalizeValues([]string{n.kind}, []string{notificationWebhook, notificationSlack, notificationEmail}, content.ErrorInvalidNotificationType)
+		if err != nil {
+			return err
+		}
+		target.Type = kinds[0]
+	}
+	if changed(content.CmdFlagURLLong) {
+		target.URL = n.url
+	}
+	if changed(content.CmdFlagEmail) {
+		target.Emails = n.emails
+	}
+	if changed(content.CmdFlagSeverityLong) {
+		levels, err := util.NormalizeValues(n.severities, []string{"High", "Medium", "Low"}, content.ErrorInvalidSeverity)
+		if err != nil {
+			return err
+		}
+		target.Levels = levels
+	}
+	if changed(content.CmdFlagFindingCloudIDLong) {
+		target.CloudAccountIDs = n.cloudIDs
+	}
+	if changed(content.CmdFlagEnabledLong) || target.ID == "" {
+		target.Enabled = n.enabled
+	}
+
+	return checkNotificationTarget(target)
+}
+
+// checkNotificationTarget checks that target has what its type needs
+func checkNotificationTarget(target *client.NotificationTarget) error {
+	if target.Name == "" {
+		return fmt.Errorf(content.ErrorNotificationNameRequired)
+	}
+
+	switch target.Type {
+	case notificationWebhook, notificationSlack:
+		u, err := url.Parse(target.URL)
+		if err != nil || target.URL == "" || u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+			return fmt.Errorf(content.ErrorNotificationURLRequired, target.Type)
+		}
+		target.Emails = nil
+	case notificationEmail:
+		if len(target.Emails) == 0 {
+			return fmt.Errorf(content.ErrorNotificationEmailRequired)
+		}
+		target.URL = ""
+	default:
+		return fmt.Errorf(content.ErrorNotificationTypeRequired)
+	}
+	return nil
+}
+
+// notificationRow is a notification target as printed in tables
+type notificationRow struct {
+	*client.NotificationTarget
+	Destination string
+	Severities  string
+	CloudIDs    string
+}
+
+// newNotificationRow hides the path of URLs, which for Slack and many
+// webhooks is the secret
+func newNotificationRow(target *client.NotificationTarget) *notificationRow {
+	row := &notificationRow{
+		NotificationTarget: target,
+		Destination:        strings.Join(target.Emails, ", "),
+		Severities:         strings.Join(target.Levels, ", "),
+		CloudIDs:           strings.Join(target.CloudAccountIDs, ", "),
+	}
+	if u, err := url.Parse(target.URL); err == nil && u.Host != "" {
+		row.Destination = u.Scheme + "://" + u.Host + "/..."
+	}
+	if row.Severities == "" {
+		row.Severities = content.InfoAllSeverities
+	}
+	if row.CloudIDs == "" {
+		row.CloudIDs = content.InfoAllCloudAccounts
+	}
+	return row
+}
+
+// printNotifications prints targets, or the only target when single is set
+func printNotifications(out io.Writer, targets []*client.NotificationTarget, single bool) {
+	var result interface{} = targets
+	switch {
+	case single && jsonFormat:
+		result = targets[0]
+	case single:
+		result = newNotificationRow(targets[0])
+	case !jsonFormat:
+		rows := make([]interface{}, len(targets))
+		for i := range targets {
+			rows[i] = newNotificationRow(targets[i])
+		}
+		result = rows
+	}
+
+	util.PrintResult(
+		out,
+		result,
+		[]string{"ID", "Name", "Type", "Destination", "Severities", "CloudIDs", "Enabled"},
+		map[string]string{
+			"ID":          "ID",
+			"Name":        "Name",
+			"Type":        "Type",
+			"Destination": "Destination",
+			"Severities":  "Severities",
+			"CloudIDs":    "Cloud Account IDs",
+			"Enabled":     "Enabled",
+		},
+		jsonFormat,
+		verbose)
+}
+
+type notificationsListCmd struct {
+	out    io.Writer
+	client command.Interface
+}
+
+func newNotificationsListCmd(client command.Interface, out io.Writer) *cobra.Command {
+	notificationsList := &notificationsListCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdListUse,
+		Short: content.CmdNotificationsListShort,
+		Long:  content.CmdNotificationsListLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if notificationsList.client == nil {
+				notificationsList.client = newCoreoClient()
+			}
+
+			return notificationsList.run()
+		},
+	}
+
+	return cmd
+}
+
+func (t *notificationsListCmd) run() error {
+	targets, err := t.client.ListNotifications(commandCtx)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 && !jsonFormat {
+		fmt.Fprintln(t.out, content.InfoNoNotifications)
+		return nil
+	}
+
+	printNotifications(t.out, targets, false)
+	return nil
+}
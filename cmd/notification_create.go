@@ -0,0 +1,71 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"io"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type notificationsCreateCmd struct {
+	out    io.Writer
+	client command.Interface
+	notificationFlags
+}
+
+func newNotificationsCreateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	notificationsCreate := &notificationsCreateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdCreateUse,
+		Short:   content.CmdNotificationsCreateShort,
+		Long:    content.CmdNotificationsCreateLong,
+		Example: content.CmdNotificationsCreateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if notificationsCreate.client == nil {
+				notificationsCreate.client = newCoreoClient()
+			}
+
+			return notificationsCreate.run()
+		},
+	}
+
+	notificationsCreate.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (t *notificationsCreateCmd) run() error {
+	target := &client.NotificationTarget{}
+	if err := t.apply(target); err != nil {
+		return err
+	}
+
+	created, err := t.client.CreateNotification(commandCtx, target)
+	if err != nil {
+		return err
+	}
+
+	printNotifications(t.out, []*client.NotificationTarget{created}, true)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type notificationsDeleteCmd struct {
+	out            io.Writer
+	client         command.Interface
+	notificationID string
+}
+
+func newNotificationsDeleteCmd(client command.Interface, out io.Writer) *cobra.Command {
+	notificationsDelete := &notificationsDeleteCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdDeleteUse,
+		Short: content.CmdNotificationsDeleteShort,
+		Long:  content.CmdNotificationsDeleteLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := util.CheckNotificationIDFlag(notificationsDelete.notificationID, verbose); err != nil {
+				return err
+			}
+
+			if notificationsDelete.client == nil {
+				notificationsDelete.client = newCoreoClient()
+			}
+
+			return notificationsDelete.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&notificationsDelete.notificationID, content.CmdFlagNotificationIDLong, "", "", content.CmdFlagNotificationIDDescription)
+
+	return cmd
+}
+
+func (t *notificationsDeleteCmd) run() error {
+	if err := t.client.DeleteNotification(commandCtx, t.notificationID); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(t.out, content.InfoNotificationDeleted)
+	return nil
+}
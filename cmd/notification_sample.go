@@ -0,0 +1,95 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type notificationsTestCmd struct {
+	out            io.Writer
+	client         command.Interface
+	notificationID string
+}
+
+func newNotificationsTestCmd(client command.Interface, out io.Writer) *cobra.Command {
+	notificationsTest := &notificationsTestCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdTestUse,
+		Short: content.CmdNotificationsTestShort,
+		Long:  content.CmdNotificationsTestLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := util.CheckNotificationIDFlag(notificationsTest.notificationID, verbose); err != nil {
+				return err
+			}
+
+			if notificationsTest.client == nil {
+				notificationsTest.client = newCoreoClient()
+			}
+
+			return notificationsTest.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&notificationsTest.notificationID, content.CmdFlagNotificationIDLong, "", "", content.CmdFlagNotificationIDDescription)
+
+	return cmd
+}
+
+// run fails when the sample notification was not delivered, so scripts can
+// check a target after changing it
+func (t *notificationsTestCmd) run() error {
+	result, err := t.client.TestNotification(commandCtx, t.notificationID)
+	if err != nil {
+		return err
+	}
+
+	if jsonFormat {
+		fmt.Fprint(t.out, util.PrettyJSON(result))
+	} else if result.Delivered {
+		fmt.Fprintf(t.out, content.InfoNotificationDelivered, t.notificationID)
+	}
+
+	if !result.Delivered {
+		return fmt.Errorf(content.ErrorNotificationNotDelivered, t.notificationID, deliveryStatus(result.StatusCode, result.Message))
+	}
+	return nil
+}
+
+// deliveryStatus describes why a notification was not delivered
+func deliveryStatus(statusCode int, message string) string {
+	switch {
+	case statusCode != 0 && message != "":
+		return fmt.Sprintf("%d %s", statusCode, message)
+	case statusCode != 0:
+		return fmt.Sprint(statusCode)
+	case message != "":
+		return message
+	default:
+		return content.InfoNoDeliveryStatus
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNotifications() []*client.NotificationTarget {
+	return []*client.NotificationTarget{
+		{ID: "n1", Name: "security", Type: "Slack", URL: "https://hooks.slack.com/services/T/B/SECRET", Levels: []string{"High"}, Enabled: true},
+		{ID: "n2", Name: "oncall", Type: "Email", Emails: []string{"oncall@example.com"}, CloudAccountIDs: []string{"c1"}},
+	}
+}
+
+func TestNotificationsListCmd(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newNotificationsListCmd(&fakeReleaseClient{notifications: testNotifications()}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	out := buf.String()
+	assert.Contains(t, out, "https://hooks.slack.com/...")
+	assert.NotContains(t, out, "SECRET")
+	assert.Contains(t, out, "oncall@example.com")
+
+	buf.Reset()
+	cmd = newNotificationsListCmd(&fakeReleaseClient{}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, content.InfoNoNotifications+"\n", buf.String())
+}
+
+func TestNotificationsCreateCmd(t *testing.T) {
+	tests := []struct {
+		desc  string
+		flags []string
+		saved *client.NotificationTarget
+		err   string
+	}{
+		{
+			desc:  "slack",
+			flags: []string{"--name", "security", "--type", "slack", "--url", "https://hooks.slack.com/services/T/B/X", "--severity", "high,medium"},
+			saved: &client.NotificationTarget{Name: "security", Type: "Slack", URL: "https://hooks.slack.com/services/T/B/X", Levels: []string{"High", "Medium"}, Enabled: true},
+		},
+		{
+			desc:  "email",
+			flags: []string{"-n", "oncall", "--type", "EMAIL", "--email", "a@example.com,b@example.com", "--cloud-id", "c1", "--enabled=false"},
+			saved: &client.NotificationTarget{Name: "oncall", Type: "Email", Emails: []string{"a@example.com", "b@example.com"}, CloudAccountIDs: []string{"c1"}},
+		},
+		{desc: "no name", flags: []string{"--type", "email", "--email", "a@example.com"}, err: content.ErrorNotificationNameRequired},
+		{desc: "no type", flags: []string{"--name", "n"}, err: content.ErrorNotificationTypeRequired},
+		{desc: "bad type", flags: []string{"--name", "n", "--type", "sms"}, err: `Invalid type "sms", use webhook, slack or email`},
+		{desc: "no url", flags: []string{"--name", "n", "--type", "webhook", "--url", "hooks.example.com"}, err: "Webhook targets need an http or https URL. Use flag '--url'\n"},
+		{desc: "no email", flags: []string{"--name", "n", "--type", "email"}, err: content.ErrorNotificationEmailRequired},
+		{desc: "bad severity", flags: []string{"--name", "n", "--type", "email", "--email", "a@example.com", "--severity", "critical"}, err: `Severity must be one of High, Medium, Low, got "critical"`},
+	}
+
+	for _, tt := range tests {
+		frc := &fakeReleaseClient{}
+		var buf bytes.Buffer
+		cmd := newNotificationsCreateCmd(frc, &buf)
+		cmd.ParseFlags(tt.flags)
+		err := cmd.RunE(cmd, nil)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, tt.desc)
+			assert.Nil(t, frc.saved, tt.desc)
+			continue
+		}
+		assert.Nil(t, err, tt.desc)
+		assert.Equal(t, tt.saved, frc.saved, tt.desc)
+		assert.Contains(t, buf.String(), "notification-id", tt.desc)
+	}
+}
+
+func TestNotificationsUpdateCmd(t *testing.T) {
+	frc := &fakeReleaseClient{notifications: testNotifications()}
+	var buf bytes.Buffer
+	cmd := newNotificationsUpdateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--notification-id", "n1", "--severity", "low", "--enabled=false"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, &client.NotificationTarget{ID: "n1", Name: "security", Type: "Slack", URL: "https://hooks.slack.com/services/T/B/SECRET", Levels: []string{"Low"}}, frc.saved)
+
+	frc.saved = nil
+	cmd = newNotificationsUpdateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--notification-id", "n2", "--type", "webhook", "--url", "https://hooks.example.com/vss"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, &client.NotificationTarget{ID: "n2", Name: "oncall", Type: "Webhook", URL: "https://hooks.example.com/vss", CloudAccountIDs: []string{"c1"}}, frc.saved)
+
+	frc.saved = nil
+	cmd = newNotificationsUpdateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--notification-id", "n2", "--type", "slack"})
+	assert.NotNil(t, cmd.RunE(cmd, nil), "slack without url")
+	assert.Nil(t, frc.saved)
+
+	cmd = newNotificationsUpdateCmd(frc, &buf)
+	assert.EqualError(t, cmd.RunE(cmd, nil), content.ErrorNotificationIDRequired)
+}
+
+func TestNotificationsDeleteCmd(t *testing.T) {
+	frc := &fakeReleaseClient{}
+	var buf bytes.Buffer
+	cmd := newNotificationsDeleteCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--notification-id", "n1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "n1", frc.deletedID)
+	assert.Equal(t, content.InfoNotificationDeleted+"\n", buf.String())
+
+	cmd = newNotificationsDeleteCmd(frc, &buf)
+	assert.NotNil(t, cmd.RunE(cmd, nil))
+}
+
+func TestNotificationsTestCmd(t *testing.T) {
+	frc := &fakeReleaseClient{testResult: client.NotificationTestResult{Delivered: true, StatusCode: 200}}
+	var buf bytes.Buffer
+	cmd := newNotificationsTestCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--notification-id", "n1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Sample notification to n1 was delivered.\n", buf.String())
+
+	tests := []struct {
+		result client.NotificationTestResult
+		err    string
+	}{
+		{result: client.NotificationTestResult{StatusCode: 404, Message: "no_service"}, err: "Sample notification to n1 was not delivered: 404 no_service"},
+		{result: client.NotificationTestResult{Message: "connection refused"}, err: "Sample notification to n1 was not delivered: connection refused"},
+		{err: "Sample notification to n1 was not delivered: no status reported"},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		cmd = newNotificationsTestCmd(&fakeReleaseClient{testResult: tt.result}, &buf)
+		cmd.ParseFlags([]string{"--notification-id", "n1"})
+		assert.EqualError(t, cmd.RunE(cmd, nil), tt.err)
+		assert.Equal(t, "", buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package main
+
+import (
+	"io"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type notificationsUpdateCmd struct {
+	out            io.Writer
+	client         command.Interface
+	notificationID string
+	notificationFlags
+}
+
+func newNotificationsUpdateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	notificationsUpdate := &notificationsUpdateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdUpdateUse,
+		Short:   content.CmdNotificationsUpdateShort,
+		Long:    content.CmdNotificationsUpdateLong,
+		Example: content.CmdNotificationsUpdateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := util.CheckNotificationIDFlag(notificationsUpdate.notificationID, verbose); err != nil {
+				return err
+			}
+
+			if notificationsUpdate.client == nil {
+				notificationsUpdate.client = newCoreoClient()
+			}
+
+			return notificationsUpdate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&notificationsUpdate.notificationID, content.CmdFlagNotificationIDLong, "", "", content.CmdFlagNotificationIDDescription)
+	notificationsUpdate.addFlags(f)
+
+	return cmd
+}
+
+// run changes only the settings whose flags were given
+func (t *notificationsUpdateCmd) run() error {
+	target, err := t.client.ShowNotificationByID(commandCtx, t.notificationID)
+	if err != nil {
+		return err
+	}
+	if err := t.apply(target); err != nil {
+		return err
+	}
+
+	updated, err := t.client.UpdateNotification(commandCtx, target)
+	if err != nil {
+		return err
+	}
+
+	printNotifications(t.out, []*client.NotificationTarget{updated}, true)
+	return nil
+}
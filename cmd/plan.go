@@ -0,0 +1,253 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// planCmd compares a manifest with the cloud accounts of the team, and with
+// apply set makes the changes
+type planCmd struct {
+	out    io.Writer
+	client command.Interface
+	cloud  func(account *manifest.CloudAccount) command.CloudProvider
+	file   string
+	apply  bool
+	prune  bool
+}
+
+func newPlanCmd(client command.Interface, out io.Writer) *cobra.Command {
+	plan := &planCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:               content.CmdPlanUse,
+		Short:             content.CmdPlanShort,
+		Long:              content.CmdPlanLong,
+		Example:           content.CmdPlanExample,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: setupCoreoConfig,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan.file = args[0]
+
+			if plan.client == nil {
+				plan.client = newCoreoClient()
+			}
+
+			return plan.run()
+		},
+	}
+
+	return cmd
+}
+
+func newApplyCmd(client command.Interface, out io.Writer) *cobra.Command {
+	apply := &planCmd{
+		out:    out,
+		client: client,
+		apply:  true,
+	}
+
+	cmd := &cobra.Command{
+		Use:               content.CmdApplyUse,
+		Short:             content.CmdApplyShort,
+		Long:              content.CmdApplyLong,
+		Example:           content.CmdApplyExample,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: setupCoreoConfig,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apply.file = args[0]
+
+			if apply.client == nil {
+				apply.client = newCoreoClient()
+			}
+
+			if apply.cloud == nil {
+				apply.cloud = (&cloudImportCmd{}).newCloudProvider
+			}
+
+			return apply.run()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&apply.prune, content.CmdFlagPruneLong, "", false, content.CmdFlagPruneDescription)
+
+	return cmd
+}
+
+func (t *planCmd) run() error {
+	m, err := manifest.Load(t.file)
+	if err != nil {
+		return err
+	}
+
+	current, err := t.client.ListCloudAccounts(commandCtx)
+	if err != nil {
+		return err
+	}
+	// the manifest names accounts, so a plan for ambiguous names would
+	// update or delete an arbitrary one of them
+	existing := map[string]*client.CloudAccount{}
+	for _, cloud := range current {
+		if other, ok := existing[cloud.Name]; ok {
+			return fmt.Errorf(content.ErrorDuplicateExistingCloudAccountName, other.ID, cloud.ID, cloud.Name)
+		}
+		existing[cloud.Name] = cloud
+	}
+	if err := checkPlanManifest(m, existing); err != nil {
+		return err
+	}
+
+	plan := manifest.NewPlan(m, current)
+	t.print(plan)
+
+	if !t.apply {
+		return nil
+	}
+	return t.applyPlan(plan)
+}
+
+// checkPlanManifest checks every account of the manifest before anything is
+// changed. Accounts to create are checked like cloud import rows, the others
+// only need a valid environment since their credentials are kept.
+func checkPlanManifest(m *manifest.Manifest, existing map[string]*client.CloudAccount) error {
+	rows := map[string]int{}
+	for i, account := range m.CloudAccounts {
+		if row, ok := rows[account.Name]; ok {
+			return fmt.Errorf("row %d: %s", i+1, fmt.Sprintf(content.ErrorDuplicateCloudAccountName, row))
+		}
+		rows[account.Name] = i + 1
+
+		var err error
+		if cloud, ok := existing[account.Name]; ok {
+			account.Provider = cloud.Provider
+			account.ExternalID = os.ExpandEnv(account.ExternalID)
+			err = util.CheckEnvironment(account.Environment)
+		} else {
+			err = checkManifestAccount(account)
+		}
+		if err != nil {
+			return fmt.Errorf("row %d (%s): %s", i+1, account.Name, err)
+		}
+	}
+	return nil
+}
+
+func (t *planCmd) print(plan *manifest.Plan) {
+	if jsonFormat {
+		fmt.Fprintln(t.out, util.PrettyJSON(plan))
+		return
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Fprintf(t.out, content.InfoPlanNoChanges, t.file)
+		return
+	}
+	for _, change := range plan.Changes {
+		if change.Action == manifest.ActionDelete && !t.prune {
+			fmt.Fprintf(t.out, "%s%s\n", change, content.InfoPlanDeleteSkipped)
+			continue
+		}
+		fmt.Fprintln(t.out, change)
+		for _, field := range change.Fields {
+			fmt.Fprintf(t.out, "    %s: %q => %q\n", field.Field, field.From, field.To)
+		}
+	}
+	fmt.Fprintf(t.out, content.InfoPlanSummary,
+		plan.Count(manifest.ActionCreate), plan.Count(manifest.ActionUpdate), plan.Count(manifest.ActionDelete))
+}
+
+// applyPlan makes the changes of plan in order and stops at the first one
+// that fails, a later apply picks up the rest since accounts are matched by
+// name
+func (t *planCmd) applyPlan(plan *manifest.Plan) error {
+	done := map[string]int{}
+	for _, change := range plan.Changes {
+		var err error
+		switch change.Action {
+		case manifest.ActionCreate:
+			var cloud *client.CloudAccount
+			cloud, err = (&cloudImportCmd{client: t.client, cloud: t.cloud}).create(change.Account)
+			if err == nil {
+				change.CloudID = cloud.ID
+			}
+		case manifest.ActionUpdate:
+			_, err = t.client.UpdateCloudAccount(commandCtx, updateInput(change))
+		case manifest.ActionDelete:
+			if !t.prune {
+				continue
+			}
+			err = t.client.DeleteCloudAccountByID(commandCtx, change.CloudID)
+		}
+		if err != nil {
+			return fmt.Errorf(content.ErrorApplyFailed, change.Action, change.Name, err,
+				done[manifest.ActionCreate], done[manifest.ActionUpdate], done[manifest.ActionDelete])
+		}
+		done[change.Action]++
+		if !jsonFormat {
+			fmt.Fprintf(t.out, content.InfoApplied, change.Action, change.Name, change.CloudID)
+		}
+	}
+
+	if !jsonFormat {
+		fmt.Fprintf(t.out, content.InfoApplyComplete,
+			done[manifest.ActionCreate], done[manifest.ActionUpdate], done[manifest.ActionDelete])
+	}
+	return nil
+}
+
+// updateInput sets the fields of the cloud account of change to those of
+// the manifest, keeping tags, role and scan settings the manifest leaves out
+func updateInput(change *manifest.Change) *client.UpdateCloudAccountInput {
+	account := change.Account
+	input := &client.UpdateCloudAccountInput{
+		CloudID: change.CloudID,
+		CreateCloudAccountInput: client.CreateCloudAccountInput{
+			CloudName:   account.Name,
+			Environment: account.Environment,
+			IsDraft:     account.IsDraft,
+			Tags:        strings.Join(account.Tags, "|"),
+			RoleArn:     account.RoleArn,
+			ExternalID:  account.ExternalID,
+			Scan:        scanSettings(account, change.Current.ScanEnabled),
+		},
+	}
+	return input
+}
+
+// scanSettings returns the scan settings of account, nil when it has none,
+// with enabled used when it does not say whether to scan
+func scanSettings(account *manifest.CloudAccount, enabled bool) *client.ScanSettings {
+	if account.ScanEnabled == nil && account.ScanInterval == "" && account.ScanRegion == "" {
+		return nil
+	}
+	if account.ScanEnabled != nil {
+		enabled = *account.ScanEnabled
+	}
+	return &client.ScanSettings{Enabled: enabled, Interval: account.ScanInterval, Region: account.ScanRegion}
+}
@@ -0,0 +1,156 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// applyClient records the cloud accounts updated and deleted
+type applyClient struct {
+	*importClient
+	updated []*client.UpdateCloudAccountInput
+	deleted []string
+}
+
+func (c *applyClient) UpdateCloudAccount(ctx context.Context, input *client.UpdateCloudAccountInput) (*client.CloudAccount, error) {
+	c.updated = append(c.updated, input)
+	return &client.CloudAccount{ID: input.CloudID}, nil
+}
+
+func (c *applyClient) DeleteCloudAccountByID(ctx context.Context, cloudID string) error {
+	c.deleted = append(c.deleted, cloudID)
+	return nil
+}
+
+const planManifest = `cloudAccounts:
+- name: prod
+  provider: AWS
+  environment: Production
+  tags: [team:core, pci]
+  scanInterval: Daily
+- name: staging
+  environment: Staging
+- name: new
+  provider: aws
+  roleArn: arn:aws:iam::123456789012:role/vss
+  externalId: ext
+  scanEnabled: false
+`
+
+func newApplyClient() *applyClient {
+	return &applyClient{importClient: &importClient{fakeReleaseClient: &fakeReleaseClient{cloudAccounts: []*client.CloudAccount{
+		{ID: "id-prod", CloudInfo: client.CloudInfo{Name: "prod", Provider: "AWS", Environment: "Staging", ScanEnabled: true,
+			ScanInterval: "Weekly", Tags: []string{"pci"}}},
+		{ID: "id-staging", CloudInfo: client.CloudInfo{Name: "staging", Provider: "AWS", Environment: "Staging"}},
+		{ID: "id-old", CloudInfo: client.CloudInfo{Name: "old", Provider: "Azure"}},
+	}}}}
+}
+
+func TestPlanCmd(t *testing.T) {
+	path, cleanup := importManifest(t, "accounts.yaml", planManifest)
+	defer cleanup()
+
+	clt := newApplyClient()
+	var buf bytes.Buffer
+	plan := &planCmd{out: &buf, client: clt, file: path}
+	assert.Nil(t, plan.run())
+
+	assert.Equal(t, `+ create new (AWS)
+~ update prod (AWS id-prod)
+    environment: "Staging" => "Production"
+    tags: "pci" => "pci|team:core"
+    scanInterval: "Weekly" => "Daily"
+- delete old (Azure id-old) (kept, apply with --prune to delete)
+Plan: 1 to create, 1 to update, 1 to delete.
+`, buf.String())
+	assert.Empty(t, clt.created, "plan changes nothing")
+	assert.Empty(t, clt.updated)
+}
+
+func TestApplyCmd(t *testing.T) {
+	path, cleanup := importManifest(t, "accounts.yaml", planManifest)
+	defer cleanup()
+
+	clt := newApplyClient()
+	var buf bytes.Buffer
+	apply := &planCmd{out: &buf, client: clt, file: path, apply: true}
+	assert.Nil(t, apply.run())
+
+	assert.Equal(t, 1, len(clt.created))
+	assert.Equal(t, "arn:aws:iam::123456789012:role/vss", clt.created[0].RoleArn)
+	assert.Equal(t, &client.ScanSettings{Enabled: false}, clt.created[0].Scan)
+	assert.Equal(t, 1, len(clt.updated))
+	assert.Equal(t, "id-prod", clt.updated[0].CloudID)
+	assert.Equal(t, "Production", clt.updated[0].Environment)
+	assert.Equal(t, "team:core|pci", clt.updated[0].Tags)
+	assert.Equal(t, &client.ScanSettings{Enabled: true, Interval: "Daily"}, clt.updated[0].Scan)
+	assert.Empty(t, clt.deleted, "deletes need --prune")
+	assert.Contains(t, buf.String(), "Apply complete: 1 created, 1 updated, 0 deleted.")
+
+	buf.Reset()
+	clt = newApplyClient()
+	apply = &planCmd{out: &buf, client: clt, file: path, apply: true, prune: true}
+	assert.Nil(t, apply.run())
+	assert.Equal(t, []string{"id-old"}, clt.deleted)
+	assert.Contains(t, buf.String(), "delete old (id-old): done")
+}
+
+func TestApplyCmdFailure(t *testing.T) {
+	path, cleanup := importManifest(t, "accounts.yaml", planManifest)
+	defer cleanup()
+
+	clt := newApplyClient()
+	clt.failNames = map[string]bool{"new": true}
+	apply := &planCmd{out: &bytes.Buffer{}, client: clt, file: path, apply: true}
+	assert.EqualError(t, apply.run(), "create new failed: invalid role (0 created, 0 updated, 0 deleted before it)")
+	assert.Empty(t, clt.updated, "apply stops at the first failure")
+}
+
+func TestPlanCmdInvalidManifest(t *testing.T) {
+	for _, tc := range []struct {
+		body string
+		err  string
+	}{
+		{"cloudAccounts:\n- name: prod\n- name: prod\n", "row 2: Name already used on row 1"},
+		{"cloudAccounts:\n- name: prod\n  environment: QA\n", "row 1 (prod): Environment must be one of those: Production, Staging, Development, Test "},
+		{"cloudAccounts:\n- name: new\n", "row 1 (new): Please either provide both externalID and roleArn or the name of the new role "},
+	} {
+		path, cleanup := importManifest(t, "accounts.yaml", tc.body)
+		clt := newApplyClient()
+		apply := &planCmd{out: &bytes.Buffer{}, client: clt, file: path, apply: true, prune: true}
+		assert.EqualError(t, apply.run(), tc.err, tc.body)
+		assert.Empty(t, clt.deleted, tc.body)
+		cleanup()
+	}
+}
+
+func TestApplyCmdDuplicateExistingNames(t *testing.T) {
+	path, cleanup := importManifest(t, "accounts.yaml", planManifest)
+	defer cleanup()
+
+	clt := newApplyClient()
+	clt.cloudAccounts = append(clt.cloudAccounts, &client.CloudAccount{ID: "id-prod-2", CloudInfo: client.CloudInfo{Name: "prod", Provider: "AWS"}})
+	apply := &planCmd{out: &bytes.Buffer{}, client: clt, file: path, apply: true, prune: true}
+	assert.EqualError(t, apply.run(), `Cloud accounts id-prod and id-prod-2 of the team are both named "prod", rename one of them since the manifest matches accounts by name`)
+	assert.Empty(t, clt.created)
+	assert.Empty(t, clt.updated)
+	assert.Empty(t, clt.deleted)
+}
@@ -0,0 +1,143 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/CloudCoreo/cli/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+func newReportCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               content.CmdReportUse,
+		Short:             content.CmdReportShort,
+		Long:              content.CmdReportLong,
+		PersistentPreRunE: setupCoreoConfig,
+	}
+
+	cmd.AddCommand(newReportComplianceCmd(nil, &ruleCatalog{ttl: defaultRuleCacheTTL}, out))
+
+	return cmd
+}
+
+type reportComplianceCmd struct {
+	out       io.Writer
+	client    command.Interface
+	catalog   *ruleCatalog
+	framework string
+	cloudIDs  []string
+	output    string
+}
+
+func newReportComplianceCmd(client command.Interface, catalog *ruleCatalog, out io.Writer) *cobra.Command {
+	reportCompliance := &reportComplianceCmd{
+		out:     out,
+		client:  client,
+		catalog: catalog,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdReportComplianceUse,
+		Short:   content.CmdReportComplianceShort,
+		Long:    content.CmdReportComplianceLong,
+		Example: content.CmdReportComplianceExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if reportCompliance.framework == "" {
+				return fmt.Errorf(content.ErrorFrameworkRequired)
+			}
+
+			if reportCompliance.client == nil {
+				reportCompliance.client = newCoreoClient()
+			}
+
+			return reportCompliance.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&reportCompliance.framework, content.CmdFlagFrameworkLong, "", "", content.CmdFlagReportFrameworkDescription)
+	f.StringSliceVar(&reportCompliance.cloudIDs, content.CmdFlagCloudIDLong, nil, content.CmdFlagReportCloudIDDescription)
+	f.StringVarP(&reportCompliance.output, content.CmdFlagOutputLong, content.CmdFlagOutputShort, "", content.CmdFlagReportOutputDescription)
+	f.BoolVar(&reportCompliance.catalog.refresh, content.CmdFlagRefreshLong, false, content.CmdFlagRefreshDescription)
+
+	return cmd
+}
+
+// fileSlug turns a framework name into something safe in a file name
+func fileSlug(name string) string {
+	return strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func (t *reportComplianceCmd) run() error {
+	rules, err := t.catalog.rules(t.client)
+	if err != nil {
+		return err
+	}
+
+	frameworkRules := report.FrameworkRules(rules, t.framework)
+	if len(frameworkRules) == 0 {
+		return fmt.Errorf(content.ErrorUnknownFramework, t.framework, strings.Join(report.Frameworks(rules), ", "))
+	}
+
+	ruleIDs := make([]string, len(frameworkRules))
+	for i, rule := range frameworkRules {
+		ruleIDs[i] = rule.ID
+	}
+
+	generatedAt := timeNow().UTC()
+	findings, err := t.client.ListFindings(commandCtx, client.FindingFilter{
+		CloudAccountIDs: t.cloudIDs,
+		RuleIDs:         ruleIDs,
+		Statuses:        []string{"Open"},
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	compliance := report.NewCompliance(t.framework, frameworkRules, findings)
+	compliance.GeneratedAt = generatedAt
+	compliance.Profile = userProfile
+	compliance.Endpoint = apiEndpoint
+	compliance.CloudAccountIDs = t.cloudIDs
+
+	output := t.output
+	if output == "" {
+		output = fmt.Sprintf(content.DefaultComplianceReportFile, fileSlug(compliance.Framework), generatedAt.Format("20060102"))
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := compliance.WriteHTML(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(t.out, content.InfoComplianceReportWritten, output, compliance.Passed(), compliance.Failed())
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportComplianceCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+	output := filepath.Join(filepath.Dir(catalog.file), "report.html")
+
+	frc := &fakeReleaseClient{rules: testRules(), findings: []*client.Finding{{RuleID: "s3-public", Status: "Open", CloudAccountID: "c1", ObjectID: "bucket-1"}}}
+	var buf bytes.Buffer
+	cmd := newReportComplianceCmd(frc, catalog, &buf)
+	cmd.ParseFlags([]string{"--framework", "cis", "--cloud-id", "c1", "-o", output})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Wrote "+output+": 0 controls passed, 1 failed\n", buf.String())
+	assert.Equal(t, client.FindingFilter{CloudAccountIDs: []string{"c1"}, RuleIDs: []string{"s3-public"}, Statuses: []string{"Open"}}, frc.findingFilter)
+
+	html, err := ioutil.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Contains(t, string(html), "bucket-1")
+	assert.Contains(t, string(html), "<td>c1</td>")
+}
+
+func TestReportComplianceCmdFailure(t *testing.T) {
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	cmd := newReportComplianceCmd(&fakeReleaseClient{}, catalog, &buf)
+	assert.EqualError(t, cmd.RunE(cmd, nil), content.ErrorFrameworkRequired)
+
+	cmd = newReportComplianceCmd(&fakeReleaseClient{rules: testRules()}, catalog, &buf)
+	cmd.ParseFlags([]string{"--framework", "HIPAA"})
+	assert.EqualError(t, cmd.RunE(cmd, nil), `No rules map to framework "HIPAA", known frameworks are: CIS, PCI`)
+}
+
+func TestFileSlug(t *testing.T) {
+	assert.Equal(t, "cis-aws-foundations-1-4", fileSlug(" CIS AWS Foundations 1.4"))
+}
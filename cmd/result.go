@@ -29,6 +29,7 @@ func newResultCmd(out io.Writer) *cobra.Command {
 		Short:             content.CmdResultShort,
 		Long:              content.CmdResultLong,
 		PersistentPreRunE: setupCoreoConfig,
+		Deprecated:        content.CmdResultDeprecated,
 	}
 
 	cmd.AddCommand(newResultRuleCmd(nil, out))
@@ -20,7 +20,6 @@ import (
 
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/pkg/command"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
@@ -40,11 +39,9 @@ func newResultObjectCmd(client command.Interface, out io.Writer) *cobra.Command
 		Long:  content.CmdResultObjectLong,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if resultObject.client == nil {
-				resultObject.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				resultObject.client = newCoreoClient()
 			}
-			_, err := fmt.Fprint(out, "Findings results are deprecated, please follow the link to swagger API doc `https://api.securestate.vmware.com` \n")
+			_, err := fmt.Fprint(out, content.InfoResultMoved)
 			return err
 		},
 	}
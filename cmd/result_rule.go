@@ -38,7 +38,7 @@ func newResultRuleCmd(client command.Interface, out io.Writer) *cobra.Command {
 		Short:   content.CmdResultRuleShort,
 		Long:    content.CmdResultRuleLong,
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Fprint(resultRule.out, "Findings results are deprecated, please follow the link to swagger API doc `https://api.securestate.vmware.com` \n")
+			fmt.Fprint(resultRule.out, content.InfoResultMoved)
 		},
 	}
 	return cmd
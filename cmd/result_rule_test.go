@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/pkg/errors"
 )
 
@@ -21,13 +22,13 @@ func TestResultRuleCmd(t *testing.T) {
 			cmds:  "coreo result object",
 			desc:  "Show violating objects",
 			flags: []string{},
-			xout: "Findings results are deprecated, please follow the link to swagger API doc `https://api.securestate.vmware.com` \n",
+			xout: content.InfoResultMoved,
 		},
 		{
 			cmds:  "coreo result object",
 			desc:  "Show violating objects",
 			flags: []string{},
-			xout: "Findings results are deprecated, please follow the link to swagger API doc `https://api.securestate.vmware.com` \n",
+			xout: content.InfoResultMoved,
 		},
 	}
 
@@ -0,0 +1,105 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/pkg/command"
+)
+
+// defaultRuleCacheTTL is how long the rule catalog is read from the cache
+const defaultRuleCacheTTL = 24 * time.Hour
+
+// ruleCacheEntry is the content of the rule cache file of a profile
+type ruleCacheEntry struct {
+	FetchedAt time.Time      `json:"fetchedAt"`
+	Rules     []*client.Rule `json:"rules"`
+}
+
+// ruleCatalog reads the rule catalog from a per profile cache file under
+// VSS_HOME while it is younger than ttl, and from the API otherwise
+type ruleCatalog struct {
+	ttl     time.Duration
+	refresh bool
+	offline bool
+	// file overrides the cache file, for tests
+	file string
+}
+
+func (r *ruleCatalog) path() string {
+	if r.file != "" {
+		return r.file
+	}
+	return filepath.Join(homePath(), content.RuleCacheFolder, userProfile+"-rules.json")
+}
+
+// load reads the cache, it fails when there is none
+func (r *ruleCatalog) load() (*ruleCacheEntry, error) {
+	b, err := ioutil.ReadFile(r.path())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &ruleCacheEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *ruleCatalog) store(rules []*client.Rule) error {
+	if err := os.MkdirAll(filepath.Dir(r.path()), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(&ruleCacheEntry{FetchedAt: timeNow(), Rules: rules})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path(), b, 0600)
+}
+
+// rules returns the catalog. With offline, the cache is used however old it is
+// and the API is never called, with refresh the cache is never read.
+func (r *ruleCatalog) rules(clt command.Interface) ([]*client.Rule, error) {
+	if !r.refresh {
+		entry, err := r.load()
+		switch {
+		case err == nil && (r.offline || timeNow().Sub(entry.FetchedAt) < r.ttl):
+			return entry.Rules, nil
+		case r.offline:
+			return nil, errors.New(content.ErrorNoRuleCache)
+		}
+	}
+
+	rules, err := clt.ListRules(commandCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.store(rules); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, content.ErrorWritingRuleCache, err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+func newRulesCmd(out io.Writer) *cobra.Command {
+	catalog := &ruleCatalog{}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdRulesUse,
+		Short: content.CmdRulesShort,
+		Long:  content.CmdRulesLong,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if catalog.offline {
+				return nil
+			}
+			return setupCoreoConfig(cmd, args)
+		},
+	}
+
+	p := cmd.PersistentFlags()
+	p.DurationVar(&catalog.ttl, content.CmdFlagCacheTTLLong, defaultRuleCacheTTL, content.CmdFlagCacheTTLDescription)
+	p.BoolVar(&catalog.refresh, content.CmdFlagRefreshLong, false, content.CmdFlagRefreshDescription)
+	p.BoolVar(&catalog.offline, content.CmdFlagOfflineLong, false, content.CmdFlagOfflineDescription)
+
+	cmd.AddCommand(newRulesListCmd(nil, catalog, out))
+	cmd.AddCommand(newRulesShowCmd(nil, catalog, out))
+
+	return cmd
+}
+
+// ruleRow is a rule as shown by rules list
+type ruleRow struct {
+	ID         string
+	Name       string
+	Provider   string
+	Service    string
+	Level      string
+	Frameworks string
+}
+
+// frameworks names the compliance frameworks rule maps to
+func frameworks(rule *client.Rule) []string {
+	names := make([]string, len(rule.Compliance))
+	for i, mapping := range rule.Compliance {
+		names[i] = mapping.Framework
+	}
+	return names
+}
+
+// matchesAny reports whether value equals any of values ignoring case, an
+// empty list matches everything
+func matchesAny(values []string, value ...string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		for _, candidate := range value {
+			if strings.EqualFold(v, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type rulesListCmd struct {
+	out        io.Writer
+	client     command.Interface
+	catalog    *ruleCatalog
+	providers  []string
+	services   []string
+	severities []string
+	frameworks []string
+	quiet      bool
+}
+
+func newRulesListCmd(client command.Interface, catalog *ruleCatalog, out io.Writer) *cobra.Command {
+	rulesList := &rulesListCmd{
+		out:     out,
+		client:  client,
+		catalog: catalog,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdListUse,
+		Short:   content.CmdRulesListShort,
+		Long:    content.CmdRulesListLong,
+		Example: content.CmdRulesListExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rulesList.client == nil && !rulesList.catalog.offline {
+				rulesList.client = newCoreoClient()
+			}
+
+			return rulesList.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringSliceVar(&rulesList.providers, content.CmdFlagFindingProviderLong, nil, content.CmdFlagRuleProviderDescription)
+	f.StringSliceVar(&rulesList.services, content.CmdFlagServiceLong, nil, content.CmdFlagServiceDescription)
+	f.StringSliceVar(&rulesList.severities, content.CmdFlagSeverityLong, nil, content.CmdFlagRuleSeverityDescription)
+	f.StringSliceVar(&rulesList.frameworks, content.CmdFlagFrameworkLong, nil, content.CmdFlagFrameworkDescription)
+	f.BoolVarP(&rulesList.quiet, content.CmdFlagQuietLong, "q", false, content.CmdFlagQuietDescription)
+
+	return cmd
+}
+
+func (t *rulesListCmd) run() error {
+	severities, err := util.NormalizeValues(t.severities, []string{"High", "Medium", "Low"}, content.ErrorInvalidSeverity)
+	if err != nil {
+		return err
+	}
+	providers, err := util.NormalizeValues(t.providers, []string{"AWS", "Azure"}, content.ErrorInvalidFindingProvider)
+	if err != nil {
+		return err
+	}
+
+	rules, err := t.catalog.rules(t.client)
+	if err != nil {
+		return err
+	}
+
+	matching := make([]*client.Rule, 0)
+	for _, rule := range rules {
+		if matchesAny(providers, rule.Provider) && matchesAny(t.services, rule.Service) &&
+			matchesAny(severities, rule.Level) && matchesAny(t.frameworks, frameworks(rule)...) {
+			matching = append(matching, rule)
+		}
+	}
+
+	if t.quiet {
+		for _, rule := range matching {
+			fmt.Fprintln(t.out, rule.ID)
+		}
+		return nil
+	}
+
+	if jsonFormat {
+		fmt.Fprint(t.out, util.PrettyJSON(matching))
+		return nil
+	}
+
+	if len(matching) == 0 {
+		fmt.Fprintln(t.out, content.InfoNoRules)
+		return nil
+	}
+
+	b := make([]interface{}, len(matching))
+	for i, rule := range matching {
+		b[i] = &ruleRow{
+			ID:         rule.ID,
+			Name:       rule.Name,
+			Provider:   rule.Provider,
+			Service:    rule.Service,
+			Level:      rule.Level,
+			Frameworks: strings.Join(frameworks(rule), ", "),
+		}
+	}
+
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"ID", "Name", "Provider", "Service", "Level", "Frameworks"},
+		map[string]string{
+			"ID":         "Rule ID",
+			"Name":       "Name",
+			"Provider":   "Provider",
+			"Service":    "Service",
+			"Level":      "Severity",
+			"Frameworks": "Compliance Frameworks",
+		},
+		false,
+		verbose)
+
+	return nil
+}
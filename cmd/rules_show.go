@@ -0,0 +1,141 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type rulesShowCmd struct {
+	out     io.Writer
+	client  command.Interface
+	catalog *ruleCatalog
+	ruleID  string
+}
+
+func newRulesShowCmd(client command.Interface, catalog *ruleCatalog, out io.Writer) *cobra.Command {
+	rulesShow := &rulesShowCmd{
+		out:     out,
+		client:  client,
+		catalog: catalog,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdShowUse,
+		Short:   content.CmdRulesShowShort,
+		Long:    content.CmdRulesShowLong,
+		Example: content.CmdRulesShowExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rulesShow.ruleID == "" {
+				return errors.New(content.ErrorRuleIDRequired)
+			}
+
+			if rulesShow.client == nil && !rulesShow.catalog.offline {
+				rulesShow.client = newCoreoClient()
+			}
+
+			return rulesShow.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&rulesShow.ruleID, content.CmdFlagRuleIDLong, "", "", content.CmdFlagShowRuleIDDescription)
+	cmd.MarkFlagCustom(content.CmdFlagRuleIDLong, content.RuleIDCompletionFunc)
+
+	return cmd
+}
+
+// findRule looks the rule up in the catalog, and asks the API for it when it
+// was added after the catalog was cached
+func (t *rulesShowCmd) findRule() (*client.Rule, error) {
+	rules, err := t.catalog.rules(t.client)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.ID == t.ruleID {
+			return rule, nil
+		}
+	}
+
+	if t.catalog.offline {
+		return nil, fmt.Errorf(content.ErrorNoRuleWithIDFound, t.ruleID)
+	}
+	return t.client.ShowRuleByID(commandCtx, t.ruleID)
+}
+
+func (t *rulesShowCmd) run() error {
+	rule, err := t.findRule()
+	if err != nil {
+		return err
+	}
+
+	if jsonFormat {
+		fmt.Fprint(t.out, util.PrettyJSON(rule))
+		return nil
+	}
+
+	fmt.Fprint(t.out, formatRule(rule))
+	return nil
+}
+
+// formatRule lays the detail of a rule out for reading in a terminal
+func formatRule(rule *client.Rule) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "ID:          %s\n", rule.ID)
+	fmt.Fprintf(buf, "Name:        %s\n", rule.Name)
+	fmt.Fprintf(buf, "Provider:    %s\n", rule.Provider)
+	fmt.Fprintf(buf, "Service:     %s\n", rule.Service)
+	fmt.Fprintf(buf, "Severity:    %s\n", rule.Level)
+
+	if len(rule.Compliance) > 0 {
+		fmt.Fprintln(buf, "Compliance:")
+		for _, mapping := range rule.Compliance {
+			if len(mapping.Controls) == 0 {
+				fmt.Fprintf(buf, "  %s\n", mapping.Framework)
+				continue
+			}
+			fmt.Fprintf(buf, "  %s: %s\n", mapping.Framework, strings.Join(mapping.Controls, ", "))
+		}
+	}
+
+	for _, section := range []struct{ title, text string }{
+		{"Description", rule.Description},
+		{"Remediation", rule.Remediation},
+	} {
+		if section.text == "" {
+			continue
+		}
+		fmt.Fprintf(buf, "\n%s:\n", section.title)
+		for _, line := range strings.Split(strings.TrimSpace(section.text), "\n") {
+			if line == "" {
+				fmt.Fprintln(buf)
+				continue
+			}
+			fmt.Fprintf(buf, "  %s\n", line)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,78 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRulesShowCmd(t *testing.T) {
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+
+	rule := &client.Rule{ID: "s3-public", Name: "Bucket is public", Provider: "AWS", Service: "s3", Level: "High",
+		Compliance:  []*client.ComplianceMapping{{Framework: "CIS", Controls: []string{"2.1", "2.2"}}, {Framework: "PCI"}},
+		Description: "The bucket can be read by anyone.",
+		Remediation: "Enable Block Public Access.\n\nThen review the bucket policy."}
+
+	var buf bytes.Buffer
+	cmd := newRulesShowCmd(&fakeReleaseClient{rules: []*client.Rule{rule}}, catalog, &buf)
+	cmd.ParseFlags([]string{"--rule-id", "s3-public"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, `ID:          s3-public
+Name:        Bucket is public
+Provider:    AWS
+Service:     s3
+Severity:    High
+Compliance:
+  CIS: 2.1, 2.2
+  PCI
+
+Description:
+  The bucket can be read by anyone.
+
+Remediation:
+  Enable Block Public Access.
+
+  Then review the bucket policy.
+`, buf.String())
+}
+
+func TestRulesShowCmdNotCached(t *testing.T) {
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+	assert.Nil(t, catalog.store(testRules()))
+
+	frc := &fakeReleaseClient{rules: append(testRules(), &client.Rule{ID: "new-rule"})}
+	var buf bytes.Buffer
+	cmd := newRulesShowCmd(frc, catalog, &buf)
+	cmd.ParseFlags([]string{"--rule-id", "new-rule"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, buf.String(), "new-rule")
+	assert.Equal(t, 1, frc.ruleCalls, "a rule missing from the cache must be fetched")
+
+	catalog.offline = true
+	cmd = newRulesShowCmd(nil, catalog, &buf)
+	cmd.ParseFlags([]string{"--rule-id", "new-rule"})
+	assert.EqualError(t, cmd.RunE(cmd, nil), "No rule with ID new-rule found in the cached rule catalog")
+
+	cmd = newRulesShowCmd(nil, catalog, &buf)
+	assert.EqualError(t, cmd.RunE(cmd, nil), content.ErrorRuleIDRequired)
+}
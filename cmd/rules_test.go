@@ -0,0 +1,119 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRules() []*client.Rule {
+	return []*client.Rule{
+		{ID: "s3-public", Name: "Bucket is public", Provider: "AWS", Service: "s3", Level: "High",
+			Compliance: []*client.ComplianceMapping{{Framework: "CIS", Controls: []string{"2.1"}}, {Framework: "PCI"}}},
+		{ID: "vm-disk", Name: "Disk is not encrypted", Provider: "Azure", Service: "compute", Level: "Medium",
+			Compliance: []*client.ComplianceMapping{{Framework: "PCI"}}},
+	}
+}
+
+// testCatalog returns a catalog cached in a temporary directory
+func testCatalog() (*ruleCatalog, func()) {
+	dir, _ := ioutil.TempDir("", "vss-rules")
+	return &ruleCatalog{ttl: time.Hour, file: filepath.Join(dir, "rules.json")}, func() { os.RemoveAll(dir) }
+}
+
+func TestRulesListCmdFilters(t *testing.T) {
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+
+	tests := []struct {
+		flags []string
+		xout  string
+	}{
+		{flags: []string{"--provider", "azure"}, xout: "vm-disk\n"},
+		{flags: []string{"--severity", "high"}, xout: "s3-public\n"},
+		{flags: []string{"--framework", "pci"}, xout: "s3-public\nvm-disk\n"},
+		{flags: []string{"--service", "S3,iam"}, xout: "s3-public\n"},
+		{flags: []string{"--framework", "CIS", "--provider", "azure"}, xout: ""},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newRulesListCmd(&fakeReleaseClient{rules: testRules()}, catalog, &buf)
+		cmd.ParseFlags(append(tt.flags, "--quiet"))
+		assert.Nil(t, cmd.RunE(cmd, nil), tt.flags)
+		assert.Equal(t, tt.xout, buf.String(), tt.flags)
+	}
+}
+
+func TestRulesListCmdTable(t *testing.T) {
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	cmd := newRulesListCmd(&fakeReleaseClient{rules: testRules()}, catalog, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, buf.String(), "CIS, PCI")
+
+	buf.Reset()
+	cmd = newRulesListCmd(&fakeReleaseClient{rules: testRules()}, catalog, &buf)
+	cmd.ParseFlags([]string{"--severity", "low"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, content.InfoNoRules+"\n", buf.String())
+}
+
+func TestRuleCatalogCache(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	catalog, cleanup := testCatalog()
+	defer cleanup()
+
+	catalog.offline = true
+	_, err := catalog.rules(nil)
+	assert.EqualError(t, err, content.ErrorNoRuleCache)
+	catalog.offline = false
+
+	frc := &fakeReleaseClient{rules: testRules()}
+	rules, err := catalog.rules(frc)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, 1, frc.ruleCalls)
+
+	rules, err = catalog.rules(frc)
+	assert.Nil(t, err)
+	assert.Equal(t, testRules(), rules)
+	assert.Equal(t, 1, frc.ruleCalls, "a fresh cache must be used")
+
+	catalog.refresh = true
+	catalog.rules(frc)
+	assert.Equal(t, 2, frc.ruleCalls, "--refresh must bypass the cache")
+	catalog.refresh = false
+
+	defer fixClock(time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC))()
+	catalog.offline = true
+	catalog.rules(frc)
+	assert.Equal(t, 2, frc.ruleCalls, "--offline must use an expired cache")
+
+	catalog.offline = false
+	catalog.rules(frc)
+	assert.Equal(t, 3, frc.ruleCalls, "an expired cache must be refetched")
+}
@@ -0,0 +1,130 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// timeNow is the clock expiries are checked against, replaced in tests
+var timeNow = time.Now
+
+func newSuppressionsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               content.CmdSuppressionsUse,
+		Short:             content.CmdSuppressionsShort,
+		Long:              content.CmdSuppressionsLong,
+		PersistentPreRunE: setupCoreoConfig,
+	}
+
+	cmd.AddCommand(newSuppressionsListCmd(nil, out))
+	cmd.AddCommand(newSuppressionsCreateCmd(nil, out))
+	cmd.AddCommand(newSuppressionsDeleteCmd(nil, out))
+	cmd.AddCommand(newSuppressionsExpiringCmd(nil, out))
+
+	return cmd
+}
+
+// suppressionExpiry parses the expiry of s, ok is false when the API returned
+// none or one that is not an RFC 3339 time
+func suppressionExpiry(s *client.Suppression) (expiry time.Time, ok bool) {
+	expiry, err := time.Parse(time.RFC3339, s.ExpiresAt)
+	return expiry, err == nil
+}
+
+// sortByExpiry orders suppressions soonest expiring first, those without a
+// valid expiry last
+func sortByExpiry(suppressions []*client.Suppression) {
+	sort.SliceStable(suppressions, func(i, j int) bool {
+		a, aOK := suppressionExpiry(suppressions[i])
+		b, bOK := suppressionExpiry(suppressions[j])
+		if aOK != bOK {
+			return aOK
+		}
+		return a.Before(b)
+	})
+}
+
+type suppressionsListCmd struct {
+	out    io.Writer
+	client command.Interface
+}
+
+func newSuppressionsListCmd(client command.Interface, out io.Writer) *cobra.Command {
+	suppressionsList := &suppressionsListCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdListUse,
+		Short: content.CmdSuppressionsListShort,
+		Long:  content.CmdSuppressionsListLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if suppressionsList.client == nil {
+				suppressionsList.client = newCoreoClient()
+			}
+
+			return suppressionsList.run()
+		},
+	}
+
+	return cmd
+}
+
+func (t *suppressionsListCmd) run() error {
+	suppressions, err := t.client.ListSuppressions(commandCtx)
+	if err != nil {
+		return err
+	}
+
+	if len(suppressions) == 0 && !jsonFormat {
+		fmt.Fprintln(t.out, content.InfoNoSuppressions)
+		return nil
+	}
+
+	sortByExpiry(suppressions)
+	b := make([]interface{}, len(suppressions))
+	for i := range suppressions {
+		b[i] = suppressions[i]
+	}
+
+	util.PrintResult(
+		t.out,
+		b,
+		[]string{"ID", "RuleID", "ObjectID", "CloudAccountID", "Owner", "ExpiresAt", "Justification"},
+		map[string]string{
+			"ID":             "ID",
+			"RuleID":         "Rule ID",
+			"ObjectID":       "Object ID",
+			"CloudAccountID": "Cloud Account ID",
+			"Owner":          "Owner",
+			"ExpiresAt":      "Expires",
+			"Justification":  "Justification",
+		},
+		jsonFormat,
+		verbose)
+
+	return nil
+}
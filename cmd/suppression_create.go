@@ -0,0 +1,126 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+type suppressionsCreateCmd struct {
+	out           io.Writer
+	client        command.Interface
+	ruleID        string
+	objectID      string
+	cloudID       string
+	justification string
+	owner         string
+	expires       string
+}
+
+func newSuppressionsCreateCmd(client command.Interface, out io.Writer) *cobra.Command {
+	suppressionsCreate := &suppressionsCreateCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     content.CmdCreateUse,
+		Short:   content.CmdSuppressionsCreateShort,
+		Long:    content.CmdSuppressionsCreateLong,
+		Example: content.CmdSuppressionsCreateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := util.CheckSuppressionCreateFlags(suppressionsCreate.ruleID, suppressionsCreate.objectID, suppressionsCreate.cloudID,
+				suppressionsCreate.justification, suppressionsCreate.owner, suppressionsCreate.expires); err != nil {
+				return err
+			}
+
+			if suppressionsCreate.client == nil {
+				suppressionsCreate.client = newCoreoClient()
+			}
+
+			return suppressionsCreate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&suppressionsCreate.ruleID, content.CmdFlagRuleIDLong, "", "", content.CmdFlagSuppressionRuleIDDescription)
+	f.StringVarP(&suppressionsCreate.objectID, content.CmdFlagObjectIDLong, "", "", content.CmdFlagSuppressionObjectIDDescription)
+	f.StringVarP(&suppressionsCreate.cloudID, content.CmdFlagCloudIDLong, "", "", content.CmdFlagSuppressionCloudIDDescription)
+	f.StringVarP(&suppressionsCreate.justification, content.CmdFlagJustificationLong, "", "", content.CmdFlagJustificationDescription)
+	f.StringVarP(&suppressionsCreate.owner, content.CmdFlagOwnerLong, "", "", content.CmdFlagOwnerDescription)
+	f.StringVarP(&suppressionsCreate.expires, content.CmdFlagExpiresLong, "", "", content.CmdFlagExpiresDescription)
+
+	return cmd
+}
+
+// parseExpiry reads a date or an RFC 3339 time that must be in the future
+func parseExpiry(value string) (time.Time, error) {
+	expiry, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		expiry, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf(content.ErrorInvalidExpires, value)
+	}
+
+	if !expiry.After(timeNow()) {
+		return time.Time{}, fmt.Errorf(content.ErrorExpiresInPast, value)
+	}
+	return expiry.UTC(), nil
+}
+
+func (t *suppressionsCreateCmd) run() error {
+	expiry, err := parseExpiry(t.expires)
+	if err != nil {
+		return err
+	}
+
+	suppression, err := t.client.CreateSuppression(commandCtx, &client.Suppression{
+		RuleID:         t.ruleID,
+		ObjectID:       t.objectID,
+		CloudAccountID: t.cloudID,
+		Justification:  t.justification,
+		Owner:          t.owner,
+		ExpiresAt:      expiry.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	util.PrintResult(
+		t.out,
+		suppression,
+		[]string{"ID", "RuleID", "ObjectID", "CloudAccountID", "Owner", "ExpiresAt"},
+		map[string]string{
+			"ID":             "ID",
+			"RuleID":         "Rule ID",
+			"ObjectID":       "Object ID",
+			"CloudAccountID": "Cloud Account ID",
+			"Owner":          "Owner",
+			"ExpiresAt":      "Expires",
+		},
+		jsonFormat,
+		verbose)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionsCreateCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+
+	frc := &fakeReleaseClient{}
+	var buf bytes.Buffer
+	cmd := newSuppressionsCreateCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--rule-id", "rule-1", "--cloud-id", "c1", "--justification", "Public website", "--owner", "web-team", "--expires", "2026-12-31"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	assert.Equal(t, &client.Suppression{
+		RuleID:         "rule-1",
+		CloudAccountID: "c1",
+		Justification:  "Public website",
+		Owner:          "web-team",
+		ExpiresAt:      "2026-12-31T00:00:00Z",
+	}, frc.created)
+	assert.Contains(t, buf.String(), "suppression-id")
+}
+
+func TestSuppressionsCreateCmdFailure(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+	required := []string{"--justification", "j", "--owner", "o", "--expires", "2026-12-31"}
+
+	tests := []struct {
+		desc  string
+		flags []string
+		err   error
+		xerr  string
+	}{
+		{desc: "no scope", flags: required, xerr: content.ErrorSuppressionScopeRequired},
+		{desc: "no justification", flags: []string{"--rule-id", "r", "--owner", "o", "--expires", "2026-12-31"}, xerr: content.ErrorJustificationRequired},
+		{desc: "no owner", flags: []string{"--rule-id", "r", "--justification", "j", "--expires", "2026-12-31"}, xerr: content.ErrorOwnerRequired},
+		{desc: "no expiry", flags: []string{"--rule-id", "r", "--justification", "j", "--owner", "o"}, xerr: content.ErrorExpiresRequired},
+		{desc: "invalid expiry", flags: []string{"--rule-id", "r", "--justification", "j", "--owner", "o", "--expires", "next week"}, xerr: `Invalid expiry date "next week", use YYYY-MM-DD or an RFC 3339 time`},
+		{desc: "past expiry", flags: []string{"--rule-id", "r", "--justification", "j", "--owner", "o", "--expires", "2026-10-18"}, xerr: "Expiry date 2026-10-18 is in the past"},
+		{desc: "api error", flags: append([]string{"--rule-id", "r"}, required...), err: errors.New("Error"), xerr: "Error"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newSuppressionsCreateCmd(&fakeReleaseClient{err: tt.err}, &buf)
+		cmd.ParseFlags(tt.flags)
+		err := cmd.RunE(cmd, nil)
+		if assert.NotNil(t, err, tt.desc) {
+			assert.Equal(t, tt.xerr, err.Error(), tt.desc)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionsDeleteCmd(t *testing.T) {
+	frc := &fakeReleaseClient{}
+	var buf bytes.Buffer
+	cmd := newSuppressionsDeleteCmd(frc, &buf)
+	cmd.ParseFlags([]string{"--suppression-id", "s1"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "s1", frc.deletedID)
+	assert.Equal(t, content.InfoSuppressionDeleted+"\n", buf.String())
+
+	cmd = newSuppressionsDeleteCmd(frc, &buf)
+	assert.NotNil(t, cmd.RunE(cmd, nil))
+}
@@ -0,0 +1,129 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/CloudCoreo/cli/cmd/util"
+	"github.com/CloudCoreo/cli/pkg/command"
+	"github.com/spf13/cobra"
+)
+
+// expiringSuppression is a suppression with how long it is still valid
+type expiringSuppression struct {
+	*client.Suppression
+	ExpiresIn string `json:"expiresIn"`
+}
+
+type suppressionsExpiringCmd struct {
+	out    io.Writer
+	client command.Interface
+	days   int
+}
+
+func newSuppressionsExpiringCmd(client command.Interface, out io.Writer) *cobra.Command {
+	suppressionsExpiring := &suppressionsExpiringCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   content.CmdSuppressionsExpiringUse,
+		Short: content.CmdSuppressionsExpiringShort,
+		Long:  content.CmdSuppressionsExpiringLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if suppressionsExpiring.days < 0 {
+				return errors.New(content.ErrorInvalidDays)
+			}
+
+			if suppressionsExpiring.client == nil {
+				suppressionsExpiring.client = newCoreoClient()
+			}
+
+			return suppressionsExpiring.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.IntVarP(&suppressionsExpiring.days, content.CmdFlagDaysLong, "", 30, content.CmdFlagDaysDescription)
+
+	return cmd
+}
+
+// expiresIn describes how long a suppression is still valid
+func expiresIn(expiry, now time.Time) string {
+	left := expiry.Sub(now)
+	switch days := int(left.Hours() / 24); {
+	case left <= 0:
+		return "expired"
+	case days == 0:
+		return "less than a day"
+	case days == 1:
+		return "1 day"
+	default:
+		return fmt.Sprintf("%d days", days)
+	}
+}
+
+func (t *suppressionsExpiringCmd) run() error {
+	suppressions, err := t.client.ListSuppressions(commandCtx)
+	if err != nil {
+		return err
+	}
+
+	now := timeNow()
+	deadline := now.AddDate(0, 0, t.days)
+	sortByExpiry(suppressions)
+
+	expiring := make([]interface{}, 0)
+	for _, suppression := range suppressions {
+		expiry, ok := suppressionExpiry(suppression)
+		switch {
+		case !ok:
+			expiring = append(expiring, &expiringSuppression{Suppression: suppression, ExpiresIn: "unknown"})
+		case expiry.Before(deadline):
+			expiring = append(expiring, &expiringSuppression{Suppression: suppression, ExpiresIn: expiresIn(expiry, now)})
+		}
+	}
+
+	if len(expiring) == 0 && !jsonFormat {
+		fmt.Fprintf(t.out, content.InfoNoExpiringSuppressions, t.days)
+		return nil
+	}
+
+	util.PrintResult(
+		t.out,
+		expiring,
+		[]string{"ID", "RuleID", "ObjectID", "CloudAccountID", "Owner", "ExpiresAt", "ExpiresIn"},
+		map[string]string{
+			"ID":             "ID",
+			"RuleID":         "Rule ID",
+			"ObjectID":       "Object ID",
+			"CloudAccountID": "Cloud Account ID",
+			"Owner":          "Owner",
+			"ExpiresAt":      "Expires",
+			"ExpiresIn":      "Expires In",
+		},
+		jsonFormat,
+		verbose)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/cmd/content"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixClock sets timeNow to now until the returned func restores it
+func fixClock(now time.Time) func() {
+	timeNow = func() time.Time { return now }
+	return func() { timeNow = time.Now }
+}
+
+func testSuppressions() []*client.Suppression {
+	return []*client.Suppression{
+		{ID: "later", RuleID: "rule-1", Owner: "a", ExpiresAt: "2026-12-31T00:00:00Z"},
+		{ID: "unknown", RuleID: "rule-2", Owner: "b"},
+		{ID: "soon", CloudAccountID: "c1", Owner: "c", ExpiresAt: "2026-10-25T00:00:00Z"},
+		{ID: "expired", ObjectID: "o1", Owner: "d", ExpiresAt: "2026-10-01T00:00:00Z"},
+	}
+}
+
+func TestSuppressionsListCmd(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newSuppressionsListCmd(&fakeReleaseClient{suppressions: testSuppressions()}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	out := buf.String()
+	assert.True(t, strings.Index(out, "expired") < strings.Index(out, "soon"))
+	assert.True(t, strings.Index(out, "later") < strings.Index(out, "unknown"))
+
+	buf.Reset()
+	cmd = newSuppressionsListCmd(&fakeReleaseClient{}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, content.InfoNoSuppressions+"\n", buf.String())
+}
+
+func TestSuppressionsExpiringCmd(t *testing.T) {
+	defer fixClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))()
+
+	var buf bytes.Buffer
+	cmd := newSuppressionsExpiringCmd(&fakeReleaseClient{suppressions: testSuppressions()}, &buf)
+	cmd.ParseFlags([]string{"--days", "30"})
+	assert.Nil(t, cmd.RunE(cmd, nil))
+
+	out := buf.String()
+	assert.Contains(t, out, "6 days")
+	assert.Contains(t, out, "unknown")
+	assert.NotContains(t, out, "later")
+
+	buf.Reset()
+	cmd = newSuppressionsExpiringCmd(&fakeReleaseClient{suppressions: testSuppressions()[:1]}, &buf)
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "No suppressions expire within 30 days.\n", buf.String())
+}
+
+func TestExpiresIn(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "expired", expiresIn(now.Add(-time.Hour), now))
+	assert.Equal(t, "less than a day", expiresIn(now.Add(time.Hour), now))
+	assert.Equal(t, "1 day", expiresIn(now.Add(30*time.Hour), now))
+	assert.Equal(t, "12 days", expiresIn(now.AddDate(0, 0, 12), now))
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/CloudCoreo/cli/cmd/content"
 	"github.com/CloudCoreo/cli/cmd/util"
 	"github.com/CloudCoreo/cli/pkg/command"
-	"github.com/CloudCoreo/cli/pkg/coreo"
 	"github.com/spf13/cobra"
 )
 
@@ -48,9 +47,7 @@ func newTokenShowCmd(client command.Interface, out io.Writer) *cobra.Command {
 			}
 
 			if tokenShow.client == nil {
-				tokenShow.client = coreo.NewClient(
-					coreo.Host(apiEndpoint),
-					coreo.RefreshToken(key))
+				tokenShow.client = newCoreoClient()
 			}
 			_, err := fmt.Fprint(out, "Tokens are deprecated, only csp token is required` \n")
 			return err
@@ -16,6 +16,7 @@ package util
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/CloudCoreo/cli/cmd/content"
 )
@@ -79,6 +80,11 @@ func CheckCloudAddFlagsForAzure(keyValue, applicationID, directoryID, subscripti
 	return checkEnvironment(environment)
 }
 
+// CheckEnvironment checks environment is one the webapp knows, or empty
+func CheckEnvironment(environment string) error {
+	return checkEnvironment(environment)
+}
+
 func checkEnvironment(environment string) error {
 	envSet := map[string]bool{
 		"Production":  true,
@@ -106,6 +112,46 @@ func CheckTokenShowOrDeleteFlag(tokenID string, verbose bool) error {
 	return nil
 }
 
+// CheckSuppressionCreateFlags flag check for suppressions create command
+func CheckSuppressionCreateFlags(ruleID, objectID, cloudID, justification, owner, expires string) error {
+	if ruleID == "" && objectID == "" && cloudID == "" {
+		return fmt.Errorf(content.ErrorSuppressionScopeRequired)
+	}
+	if err := checkFlag(justification, content.ErrorJustificationRequired); err != nil {
+		return err
+	}
+	if err := checkFlag(owner, content.ErrorOwnerRequired); err != nil {
+		return err
+	}
+	return checkFlag(expires, content.ErrorExpiresRequired)
+}
+
+// CheckSuppressionDeleteFlag flag check for suppressions delete command
+func CheckSuppressionDeleteFlag(suppressionID string, verbose bool) error {
+	if err := checkFlag(suppressionID, content.ErrorSuppressionIDRequired); err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Printf(content.InfoUsingSuppressionID, suppressionID)
+	}
+
+	return nil
+}
+
+// CheckNotificationIDFlag flag check for notifications commands
+func CheckNotificationIDFlag(notificationID string, verbose bool) error {
+	if err := checkFlag(notificationID, content.ErrorNotificationIDRequired); err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Printf(content.InfoUsingNotificationID, notificationID)
+	}
+
+	return nil
+}
+
 // CheckAPIKeyFlag flag check for api key
 func CheckAPIKeyFlag(apiKey string, userProfile string) (string, error) {
 	if apiKey == content.None {
@@ -120,9 +166,73 @@ func CheckAPIKeyFlag(apiKey string, userProfile string) (string, error) {
 	return apiKey, nil
 }
 
+// CheckClientCredentialsFlag completes the OAuth app flags with the client ID,
+// secret and org ID of the profile. The client ID is empty when neither the
+// flags nor the profile use an OAuth app.
+func CheckClientCredentialsFlag(clientID, clientSecret, orgID, userProfile string) (string, string, string, error) {
+	authType := CheckProfileFlag("", userProfile, content.AuthType)
+	if clientID == "" && authType != content.AuthTypeOAuthApp {
+		return "", "", "", nil
+	}
+
+	// The secret and organization stored for the app of the profile also
+	// apply when its client ID is passed again on the command line
+	if clientID == "" || clientID == CheckProfileFlag("", userProfile, content.ClientID) {
+		clientID = CheckProfileFlag(clientID, userProfile, content.ClientID)
+		clientSecret = CheckProfileFlag(clientSecret, userProfile, content.ClientSecret)
+		orgID = CheckProfileFlag(orgID, userProfile, content.OrgID)
+	}
+	if clientID == "" {
+		return "", "", "", fmt.Errorf(content.ErrorClientIDMissing)
+	}
+	if clientSecret == "" {
+		return clientID, "", orgID, fmt.Errorf(content.ErrorClientSecretMissing)
+	}
+	return clientID, clientSecret, orgID, nil
+}
+
+// CheckCSPURLFlag falls back to the CSP URL of the profile when none was passed,
+// an empty result means the production CSP
+func CheckCSPURLFlag(cspURL string, userProfile string) string {
+	return CheckProfileFlag(cspURL, userProfile, content.CSPURL)
+}
+
+// CheckProfileFlag falls back to the setting name of the profile when the flag
+// value is empty, the result is empty when neither is set
+func CheckProfileFlag(value, userProfile, name string) string {
+	if value != "" {
+		return value
+	}
+
+	if value = GetValueFromConfig(fmt.Sprintf("%s.%s", userProfile, name), false); value == content.None {
+		return ""
+	}
+	return value
+}
+
 func CheckProviderFlag(provider string) error {
 	if provider != "AWS" && provider != "Azure" {
 		return fmt.Errorf(content.ErrorProviderNotSupported)
 	}
 	return nil
 }
+
+// NormalizeValues matches values case-insensitively against allowed and returns
+// them spelled as in allowed, errorFormat reports the first unknown value
+func NormalizeValues(values, allowed []string, errorFormat string) ([]string, error) {
+	var res []string
+	for _, value := range values {
+		found := false
+		for _, a := range allowed {
+			if strings.EqualFold(value, a) {
+				res = append(res, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(errorFormat, value)
+		}
+	}
+	return res, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/CloudCoreo/cli/cmd/content"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +43,46 @@ func TestCheckAPIKeyFlagFailure(t *testing.T) {
 	assert.Equal(t, content.ErrorAPIKeyMissing, err.Error())
 }
 
+func TestCheckCSPURLFlag(t *testing.T) {
+	assert.Equal(t, "https://csp.example.com", CheckCSPURLFlag("https://csp.example.com", "default"))
+	assert.Equal(t, "", CheckCSPURLFlag("", "invalid"), "missing CSP_URL should fall back to the default CSP")
+
+	viper.Set("staging.CSP_URL", "https://csp.staging.example.com")
+	defer viper.Set("staging.CSP_URL", nil)
+	assert.Equal(t, "https://csp.staging.example.com", CheckCSPURLFlag("", "staging"))
+	assert.Equal(t, "https://csp.example.com", CheckCSPURLFlag("https://csp.example.com", "staging"), "flag should override the profile")
+}
+
+func TestCheckClientCredentialsFlag(t *testing.T) {
+	id, _, _, err := CheckClientCredentialsFlag("", "", "", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, "", id, "API token profiles should not use an OAuth app")
+
+	viper.Set("ci.AUTH_TYPE", "oauth_app")
+	viper.Set("ci.CLIENT_ID", "app-id")
+	viper.Set("ci.CLIENT_SECRET", "app-secret")
+	viper.Set("ci.ORG_ID", "org-1")
+	defer viper.Set("ci", nil)
+
+	id, secret, org, err := CheckClientCredentialsFlag("", "", "", "ci")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"app-id", "app-secret", "org-1"}, []string{id, secret, org})
+
+	_, secret, _, err = CheckClientCredentialsFlag("", "env-secret", "", "ci")
+	assert.Nil(t, err)
+	assert.Equal(t, "env-secret", secret, "the secret flag should override the profile")
+
+	id, secret, org, err = CheckClientCredentialsFlag("app-id", "", "", "ci")
+	assert.Nil(t, err, "the stored secret should be used for the stored client ID")
+	assert.Equal(t, []string{"app-id", "app-secret", "org-1"}, []string{id, secret, org})
+
+	_, _, _, err = CheckClientCredentialsFlag("other-app", "", "", "ci")
+	assert.NotNil(t, err, "the stored secret should not be used for another client ID")
+
+	_, _, _, err = CheckClientCredentialsFlag("other-app", "", "", "default")
+	assert.NotNil(t, err, "a client ID without secret should be rejected")
+}
+
 func TestCheckCloudAddFlagsFailure(t *testing.T) {
 	err := CheckCloudAddFlagsForAWS("", "", "", "")
 	assert.NotNil(t, err, "TestCloudAddFlagsFailure should return error")
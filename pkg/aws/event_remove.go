@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -48,46 +49,63 @@ func (a *RemoveService) newSession() (*session.Session, error) {
 	return sess, nil
 }
 
-func (a *RemoveService) snsPublish(sess *session.Session, arnType, region, cloudAccountID, topicName string) error {
+func (a *RemoveService) snsPublish(ctx context.Context, sess *session.Session, arnType, region, cloudAccountID, topicName string) error {
 	svc := sns.New(sess, aws.NewConfig().WithRegion(region))
 	topicArn := fmt.Sprintf("arn:%s:sns:%s:%s:%s", arnType, region, cloudAccountID, topicName)
 	publishInput := &sns.PublishInput{
 		Message:  aws.String("UnsubscribeConfirmation"),
 		TopicArn: aws.String(topicArn),
 	}
-	_, err := svc.Publish(publishInput)
+	_, err := svc.PublishWithContext(ctx, publishInput)
 	return err
 }
 
 //RemoveEventStream perform the same function as event stream removal script
-func (a *RemoveService) RemoveEventStream(input *client.EventRemoveConfig) error {
+func (a *RemoveService) RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error {
 	regions := input.Regions
 	sess, err := a.newSession()
 	if err != nil {
 		return err
 	}
 	fmt.Println("Deactivating devTime for cloud account", input.CloudAccountID)
-	for _, region := range regions {
-		err := a.snsPublish(sess, input.ArnType, region, input.CloudAccountID, input.TopicName)
+	for i, region := range regions {
+		if ctx.Err() != nil {
+			return a.interrupted(ctx, regions, i)
+		}
+
+		err := a.snsPublish(ctx, sess, input.ArnType, region, input.CloudAccountID, input.TopicName)
 		if err != nil {
 			fmt.Println(err.Error())
 		}
 
 		// Delete stack
-		err = a.deleteStack(sess, region, input.StackName)
+		err = a.deleteStack(ctx, sess, region, input.StackName)
 		if err != nil {
+			if ctx.Err() != nil {
+				return a.interrupted(ctx, regions, i)
+			}
 			fmt.Println(err.Error())
 		}
 	}
 	return nil
 }
 
-func (a *RemoveService) deleteStack(sess *session.Session, region, stackName string) error {
+// interrupted reports the regions removed before the region at index next
+func (a *RemoveService) interrupted(ctx context.Context, regions []string, next int) error {
+	return &client.InterruptedError{
+		Operation: "Event stream removal",
+		Completed: regions[:next],
+		Remaining: regions[next:],
+		Err:       ctx.Err(),
+	}
+}
+
+func (a *RemoveService) deleteStack(ctx context.Context, sess *session.Session, region, stackName string) error {
 	fmt.Println("Deleting", stackName, "on", region)
 	cloudFormation := cloudformation.New(sess, aws.NewConfig().WithRegion(region))
 	deleteStackInput := &cloudformation.DeleteStackInput{
 		StackName: aws.String(stackName),
 	}
-	_, err := cloudFormation.DeleteStack(deleteStackInput)
+	_, err := cloudFormation.DeleteStackWithContext(ctx, deleteStackInput)
 	return err
 }
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -49,7 +50,7 @@ func (a *SetupService) newSession() (*session.Session, error) {
 }
 
 //SetupEventStream sets up event stream for aws account
-func (a *SetupService) SetupEventStream(input *client.EventStreamConfig) error {
+func (a *SetupService) SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error {
 	regions := input.Regions
 
 	sess, err := a.newSession()
@@ -57,10 +58,17 @@ func (a *SetupService) SetupEventStream(input *client.EventStreamConfig) error {
 		return err
 	}
 
-	for _, region := range regions {
+	for i, region := range regions {
+		if ctx.Err() != nil {
+			return a.interrupted(ctx, regions, i)
+		}
+
 		// Check CloudTrail
-		_, err := a.checkCloudTrailForRegion(sess, region)
+		_, err := a.checkCloudTrailForRegion(ctx, sess, region)
 		if err != nil {
+			if ctx.Err() != nil {
+				return a.interrupted(ctx, regions, i)
+			}
 			if a.ignoreMissingTrail {
 				fmt.Println("CloudTrail is not enabled in region " + region + ". Skip event stream setup for this region.")
 				continue
@@ -70,18 +78,24 @@ func (a *SetupService) SetupEventStream(input *client.EventStreamConfig) error {
 		}
 
 		// Set up event stream
-		res := a.checkStack(sess, region, input)
+		res := a.checkStack(ctx, sess, region, input)
 		if res {
 			fmt.Println("Updating stack in " + region)
-			err := a.updateStack(sess, region, input)
+			err := a.updateStack(ctx, sess, region, input)
 			if err != nil {
+				if ctx.Err() != nil {
+					return a.interrupted(ctx, regions, i)
+				}
 				return client.NewError(err.Error() + " in region" + region)
 			}
 			fmt.Println("Successfully updated stack on region " + region)
 		} else {
 			fmt.Println("Installing stack in " + region)
-			err := a.installStack(sess, region, input)
+			err := a.installStack(ctx, sess, region, input)
 			if err != nil {
+				if ctx.Err() != nil {
+					return a.interrupted(ctx, regions, i)
+				}
 				return client.NewError(err.Error() + " in region" + region)
 			}
 			fmt.Println("Successfully installed stack on region " + region)
@@ -91,14 +105,24 @@ func (a *SetupService) SetupEventStream(input *client.EventStreamConfig) error {
 	return nil
 }
 
-func (a *SetupService) checkCloudTrailForRegion(sess *session.Session, region string) (bool, error) {
+// interrupted reports the regions set up before the region at index next
+func (a *SetupService) interrupted(ctx context.Context, regions []string, next int) error {
+	return &client.InterruptedError{
+		Operation: "Event stream setup",
+		Completed: regions[:next],
+		Remaining: regions[next:],
+		Err:       ctx.Err(),
+	}
+}
+
+func (a *SetupService) checkCloudTrailForRegion(ctx context.Context, sess *session.Session, region string) (bool, error) {
 	// Set the Region to fetch CloudTrail information to region
 	// WithRegion returns a new Config pointer that can be chained with builder
 	// methods to set multiple configuration values inline without using pointers
 	fmt.Println("Verifying that cloudtrail is enabled for region ", region)
 	cloudTrail := cloudtrail.New(sess, aws.NewConfig().WithRegion(region))
 	input := &cloudtrail.DescribeTrailsInput{}
-	output, err := cloudTrail.DescribeTrails(input)
+	output, err := cloudTrail.DescribeTrailsWithContext(ctx, input)
 	if err != nil {
 		return false, err
 	}
@@ -164,9 +188,9 @@ func (a *SetupService) newUpdateStackInput(config *client.EventStreamConfig) *cl
 	return input
 }
 
-func (a *SetupService) updateStack(sess *session.Session, region string, config *client.EventStreamConfig) error {
+func (a *SetupService) updateStack(ctx context.Context, sess *session.Session, region string, config *client.EventStreamConfig) error {
 	cloudFormation := cloudformation.New(sess, aws.NewConfig().WithRegion(region))
-	_, err := cloudFormation.UpdateStack(a.newUpdateStackInput(config))
+	_, err := cloudFormation.UpdateStackWithContext(ctx, a.newUpdateStackInput(config))
 	return err
 }
 
@@ -180,16 +204,16 @@ func (a *SetupService) newCreateStackInput(config *client.EventStreamConfig) *cl
 	return input
 }
 
-func (a *SetupService) installStack(sess *session.Session, region string, config *client.EventStreamConfig) error {
+func (a *SetupService) installStack(ctx context.Context, sess *session.Session, region string, config *client.EventStreamConfig) error {
 	cloudFormation := cloudformation.New(sess, aws.NewConfig().WithRegion(region))
-	_, err := cloudFormation.CreateStack(a.newCreateStackInput(config))
+	_, err := cloudFormation.CreateStackWithContext(ctx, a.newCreateStackInput(config))
 	return err
 }
 
-func (a *SetupService) checkStack(sess *session.Session, region string, config *client.EventStreamConfig) bool {
+func (a *SetupService) checkStack(ctx context.Context, sess *session.Session, region string, config *client.EventStreamConfig) bool {
 	cloudFormation := cloudformation.New(sess, aws.NewConfig().WithRegion(region))
 	input := &cloudformation.DescribeStacksInput{StackName: &config.StackName}
-	output, err := cloudFormation.DescribeStacks(input)
+	output, err := cloudFormation.DescribeStacksWithContext(ctx, input)
 	if err != nil {
 		return false
 	}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/CloudCoreo/cli/client"
 	"github.com/pkg/errors"
 
@@ -44,16 +46,19 @@ func (c *RoleService) createAssumeRolePolicyDocument(awsAccount string, external
 }
 
 // CreateNewRole created a role with specified policy attached
-func (c *RoleService) CreateNewRole(input *client.RoleCreationInfo) (arn string, externalID string, err error) {
+func (c *RoleService) CreateNewRole(ctx context.Context, input *client.RoleCreationInfo) (arn string, externalID string, err error) {
 	sess, err := c.newSession()
+	if err != nil {
+		return "", "", err
+	}
 	svc := iam.New(sess)
 	// Create a new session for iam
-	result, err := c.createNewAwsRole(input.AwsAccount, input.ExternalID, input.RoleName, svc)
+	result, err := c.createNewAwsRole(ctx, input.AwsAccount, input.ExternalID, input.RoleName, svc)
 	if err != nil {
 		return "", "", err
 	}
 	roleArn := result.Role.Arn
-	_, err = c.attachRolePolicy(svc, input.Policy, input.RoleName)
+	_, err = c.attachRolePolicy(ctx, svc, input.Policy, input.RoleName)
 	if err != nil {
 		return "", "", err
 	}
@@ -61,14 +66,14 @@ func (c *RoleService) CreateNewRole(input *client.RoleCreationInfo) (arn string,
 	return *roleArn, input.ExternalID, nil
 }
 
-func (c *RoleService) createNewAwsRole(awsAccount, externalID, roleName string, svc *iam.IAM) (*iam.CreateRoleOutput, error) {
+func (c *RoleService) createNewAwsRole(ctx context.Context, awsAccount, externalID, roleName string, svc *iam.IAM) (*iam.CreateRoleOutput, error) {
 	input := &iam.CreateRoleInput{
 		AssumeRolePolicyDocument: aws.String(c.createAssumeRolePolicyDocument(awsAccount, externalID)),
 		Path:     aws.String("/"),
 		RoleName: aws.String(roleName),
 	}
 
-	result, err := svc.CreateRole(input)
+	result, err := svc.CreateRoleWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -76,13 +81,13 @@ func (c *RoleService) createNewAwsRole(awsAccount, externalID, roleName string,
 	return result, nil
 }
 
-func (c *RoleService) attachRolePolicy(svc *iam.IAM, policyArn, roleName string) (*iam.AttachRolePolicyOutput, error) {
+func (c *RoleService) attachRolePolicy(ctx context.Context, svc *iam.IAM, policyArn, roleName string) (*iam.AttachRolePolicyOutput, error) {
 	input := &iam.AttachRolePolicyInput{
 		PolicyArn: aws.String(policyArn),
 		RoleName:  aws.String(roleName),
 	}
 
-	result, err := svc.AttachRolePolicy(input)
+	result, err := svc.AttachRolePolicyWithContext(ctx, input)
 	return result, err
 }
 
@@ -109,7 +114,7 @@ func (c *RoleService) newSession() (*session.Session, error) {
 }
 
 //DetachPolicy removes all policy for the role
-func (c *RoleService) DetachPolicy(roleName, policyArn string) error {
+func (c *RoleService) DetachPolicy(ctx context.Context, roleName, policyArn string) error {
 	sess, err := c.newSession()
 
 	if err != nil {
@@ -121,7 +126,7 @@ func (c *RoleService) DetachPolicy(roleName, policyArn string) error {
 		PolicyArn: aws.String(policyArn),
 		RoleName:  aws.String(roleName),
 	}
-	_, err = svc.DetachRolePolicy(detachPolicyInput)
+	_, err = svc.DetachRolePolicyWithContext(ctx, detachPolicyInput)
 	if err != nil {
 		return errors.New("Detach role policy " + policyArn + "for " + roleName + " failed, " + err.Error())
 	}
@@ -130,7 +135,7 @@ func (c *RoleService) DetachPolicy(roleName, policyArn string) error {
 }
 
 // DeleteRole will remove the role created before if the cloud account add fails
-func (c *RoleService) DeleteRole(roleName string) error {
+func (c *RoleService) DeleteRole(ctx context.Context, roleName string) error {
 	sess, err := c.newSession()
 
 	if err != nil {
@@ -139,14 +144,14 @@ func (c *RoleService) DeleteRole(roleName string) error {
 
 	svc := iam.New(sess)
 
-	policies, err := c.getManagedRolePolicies(svc, roleName)
+	policies, err := c.getManagedRolePolicies(ctx, svc, roleName)
 	for _, policy := range policies {
 		policyArn := *(policy.PolicyArn)
 		detachPolicyInput := &iam.DetachRolePolicyInput{
 			PolicyArn: aws.String(policyArn),
 			RoleName:  aws.String(roleName),
 		}
-		_, err = svc.DetachRolePolicy(detachPolicyInput)
+		_, err = svc.DetachRolePolicyWithContext(ctx, detachPolicyInput)
 		if err != nil {
 			return errors.New("Detach role policy " + policyArn + "for " + roleName + " failed, " + err.Error())
 		}
@@ -155,14 +160,14 @@ func (c *RoleService) DeleteRole(roleName string) error {
 	deleteRoleInput := &iam.DeleteRoleInput{
 		RoleName: aws.String(roleName),
 	}
-	_, err = svc.DeleteRole(deleteRoleInput)
+	_, err = svc.DeleteRoleWithContext(ctx, deleteRoleInput)
 	if err != nil {
 		return errors.New("Delete role " + roleName + " failed, " + err.Error())
 	}
 	return nil
 }
 
-func (c *RoleService) checkRolePolicy(roleName, policy string) (bool, error) {
+func (c *RoleService) checkRolePolicy(ctx context.Context, roleName, policy string) (bool, error) {
 	sess, err := c.newSession()
 
 	if err != nil {
@@ -172,7 +177,7 @@ func (c *RoleService) checkRolePolicy(roleName, policy string) (bool, error) {
 	svc := iam.New(sess)
 	input := &iam.ListAttachedRolePoliciesInput{}
 	input.SetRoleName(roleName)
-	res, err := svc.ListAttachedRolePolicies(input)
+	res, err := svc.ListAttachedRolePoliciesWithContext(ctx, input)
 	if err != nil {
 		return false, err
 	}
@@ -184,13 +189,13 @@ func (c *RoleService) checkRolePolicy(roleName, policy string) (bool, error) {
 	return false, nil
 }
 
-func (c *RoleService) getManagedRolePolicies(svc *iam.IAM, roleName string) ([]*iam.AttachedPolicy, error) {
+func (c *RoleService) getManagedRolePolicies(ctx context.Context, svc *iam.IAM, roleName string) ([]*iam.AttachedPolicy, error) {
 	res := make([]*iam.AttachedPolicy, 0)
 
 	input := &iam.ListAttachedRolePoliciesInput{
 		RoleName: &roleName,
 	}
-	err := svc.ListAttachedRolePoliciesPages(input, func(output *iam.ListAttachedRolePoliciesOutput, last bool) bool {
+	err := svc.ListAttachedRolePoliciesPagesWithContext(ctx, input, func(output *iam.ListAttachedRolePoliciesOutput, last bool) bool {
 		res = append(res, output.AttachedPolicies...)
 		return true
 	})
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/CloudCoreo/cli/client"
@@ -33,18 +34,18 @@ func NewService(input *NewServiceInput) *Service {
 }
 
 // SetupEventStream calls the SetupEventStream function in SetupService
-func (s *Service) SetupEventStream(input *client.EventStreamConfig) error {
-	return s.setup.SetupEventStream(input)
+func (s *Service) SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error {
+	return s.setup.SetupEventStream(ctx, input)
 }
 
 // CreateNewRole calls the CreateNewRole function in RoleService
-func (s *Service) CreateNewRole(input *client.RoleCreationInfo) (arn string, externalID string, err error) {
-	return s.role.CreateNewRole(input)
+func (s *Service) CreateNewRole(ctx context.Context, input *client.RoleCreationInfo) (arn string, externalID string, err error) {
+	return s.role.CreateNewRole(ctx, input)
 }
 
 // DeleteRole calls the DeleteRole function in RoleService
-func (s *Service) DeleteRole(roleName string) {
-	err := s.role.DeleteRole(roleName)
+func (s *Service) DeleteRole(ctx context.Context, roleName string) {
+	err := s.role.DeleteRole(ctx, roleName)
 	if err != nil {
 		fmt.Println(err.Error())
 	} else {
@@ -53,6 +54,6 @@ func (s *Service) DeleteRole(roleName string) {
 }
 
 //RemoveEventStream perform the same function as event stream removal script
-func (s *Service) RemoveEventStream(input *client.EventRemoveConfig) error {
-	return s.remove.RemoveEventStream(input)
+func (s *Service) RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error {
+	return s.remove.RemoveEventStream(ctx, input)
 }
@@ -28,13 +28,28 @@ func NewRemoveService(input *NewServiceInput) *RemoveService {
 }
 
 //RemoveEventStream removes Azure event stream
-func (a *RemoveService) RemoveEventStream(input *client.EventRemoveConfig) error {
-	ctx := context.Background()
+func (a *RemoveService) RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error {
 	err := a.removeResourceGroup(ctx, input)
 	if err != nil {
+		if ctx.Err() != nil {
+			return a.interrupted(ctx, nil, []string{"resource group " + input.ResourceGroup, "stream removal event"})
+		}
 		return err
 	}
-	return a.sendRemoveEvent(input)
+	err = a.sendRemoveEvent(ctx, input)
+	if err != nil && ctx.Err() != nil {
+		return a.interrupted(ctx, []string{"resource group " + input.ResourceGroup}, []string{"stream removal event"})
+	}
+	return err
+}
+
+func (a *RemoveService) interrupted(ctx context.Context, completed, remaining []string) error {
+	return &client.InterruptedError{
+		Operation: "Event stream removal",
+		Completed: completed,
+		Remaining: remaining,
+		Err:       ctx.Err(),
+	}
 }
 
 func (a *RemoveService) removeResourceGroup(ctx context.Context, input *client.EventRemoveConfig) error {
@@ -64,7 +79,7 @@ func (a *RemoveService) getGroupsClient(input *client.EventRemoveConfig) (*resou
 	return &groupsClient, nil
 }
 
-func (a *RemoveService) sendRemoveEvent(input *client.EventRemoveConfig) error {
+func (a *RemoveService) sendRemoveEvent(ctx context.Context, input *client.EventRemoveConfig) error {
 	fmt.Println("Sending Event Removal message")
 	data := fmt.Sprintf("{\"data\": {\"context\": {\"activityLog\": {\"subscriptionId\": \"%s\", \"operationName\": \"AzureStreamNotReady\"}}}}", input.SubscriptionID)
 	req, err := http.NewRequest("POST", input.WebhookServiceURI, bytes.NewBuffer([]byte(data)))
@@ -72,9 +87,12 @@ func (a *RemoveService) sendRemoveEvent(input *client.EventRemoveConfig) error {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
 	io.Copy(ioutil.Discard, resp.Body)
-	defer resp.Body.Close()
+	resp.Body.Close()
 
-	return err
+	return nil
 }
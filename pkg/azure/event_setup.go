@@ -30,21 +30,41 @@ func NewSetupService(input *NewServiceInput) *SetupService {
 }
 
 //SetupEventStream sets up Azure event stream
-func (a *SetupService) SetupEventStream(input *client.EventStreamConfig) error {
-	ctx := context.Background()
-	err := a.createResourceGroup(ctx, input)
-	if err != nil {
-		return err
+func (a *SetupService) SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error {
+	steps := []struct {
+		name string
+		run  func(context.Context, *client.EventStreamConfig) error
+	}{
+		{"resource group " + input.ResourceGroup, a.createResourceGroup},
+		{"action group " + input.ActionGroup, a.deployActionGroup},
+		{"alert " + input.AlertName, a.deployAlertBestEffort},
+		{"stream ready event", a.sendSuccessEvent},
 	}
 
-	err = a.deployActionGroup(ctx, input)
-	if err != nil {
-		return err
+	var completed []string
+	for i, step := range steps {
+		err := ctx.Err()
+		if err == nil {
+			err = step.run(ctx, input)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				return err
+			}
+			var remaining []string
+			for _, s := range steps[i:] {
+				remaining = append(remaining, s.name)
+			}
+			return &client.InterruptedError{
+				Operation: "Event stream setup",
+				Completed: completed,
+				Remaining: remaining,
+				Err:       ctx.Err(),
+			}
+		}
+		completed = append(completed, step.name)
 	}
-
-	err = a.deployAlert(ctx, input)
-
-	return a.sendSuccessEvent(input)
+	return nil
 }
 
 func (a *SetupService) createResourceGroup(ctx context.Context, input *client.EventStreamConfig) error {
@@ -117,6 +137,15 @@ func (a *SetupService) deployActionGroup(ctx context.Context, input *client.Even
 	return err
 }
 
+// deployAlertBestEffort ignores a failed alert deployment like the setup
+// always did, unless it failed because the setup was cancelled
+func (a *SetupService) deployAlertBestEffort(ctx context.Context, input *client.EventStreamConfig) error {
+	if err := a.deployAlert(ctx, input); err != nil && ctx.Err() != nil {
+		return err
+	}
+	return nil
+}
+
 func (a *SetupService) deployAlert(ctx context.Context, input *client.EventStreamConfig) error {
 	deploymentsClient, err := a.getDeploymentsClient(input)
 	if err != nil {
@@ -142,7 +171,7 @@ func (a *SetupService) deployAlert(ctx context.Context, input *client.EventStrea
 	return err
 }
 
-func (a *SetupService) sendSuccessEvent(input *client.EventStreamConfig) error {
+func (a *SetupService) sendSuccessEvent(ctx context.Context, input *client.EventStreamConfig) error {
 	//No additional whitespace is allowed in the below string, other with the http request may fail
 	//TODO: Discuss to see whether it needs to be a struct
 	data := fmt.Sprintf("{\"data\": {\"context\": {\"activityLog\": {\"subscriptionId\": \"%s\", \"operationName\": \"AzureStreamReady\"}}}}", input.SubscriptionID)
@@ -151,11 +180,14 @@ func (a *SetupService) sendSuccessEvent(input *client.EventStreamConfig) error {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
 	io.Copy(ioutil.Discard, resp.Body)
-	defer resp.Body.Close()
+	resp.Body.Close()
 
-	return err
+	return nil
 }
 
 func readJSON(s string) *map[string]interface{} {
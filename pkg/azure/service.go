@@ -1,6 +1,8 @@
 package azure
 
 import (
+	"context"
+
 	"github.com/CloudCoreo/cli/client"
 )
 
@@ -25,21 +27,21 @@ func NewService(input *NewServiceInput) *Service {
 }
 
 // SetupEventStream calls the SetupEventStream function in SetupService
-func (s *Service) SetupEventStream(input *client.EventStreamConfig) error {
-	return s.setup.SetupEventStream(input)
+func (s *Service) SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error {
+	return s.setup.SetupEventStream(ctx, input)
 }
 
 // CreateNewRole calls the CreateNewRole function in RoleService
-func (s *Service) CreateNewRole(input *client.RoleCreationInfo) (arn string, externalID string, err error) {
+func (s *Service) CreateNewRole(ctx context.Context, input *client.RoleCreationInfo) (arn string, externalID string, err error) {
 	return "", "", nil
 }
 
 // DeleteRole calls the DeleteRole function in RoleService
-func (s *Service) DeleteRole(roleName string) {
+func (s *Service) DeleteRole(ctx context.Context, roleName string) {
 
 }
 
 //RemoveEventStream perform the same function as event stream removal script
-func (s *Service) RemoveEventStream(input *client.EventRemoveConfig) error {
-	return s.remove.RemoveEventStream(input)
+func (s *Service) RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error {
+	return s.remove.RemoveEventStream(ctx, input)
 }
@@ -0,0 +1,217 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//Package baseline records accepted findings so that only new ones fail a CI gate
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+//Version of the baseline file format written
+const Version = 1
+
+//Entry is an accepted finding
+type Entry struct {
+	Fingerprint    string `json:"fingerprint"`
+	RuleID         string `json:"ruleId"`
+	Provider       string `json:"provider"`
+	CloudAccountID string `json:"cloudAccountId"`
+	Region         string `json:"region"`
+	ObjectID       string `json:"objectId"`
+	Severity       string `json:"severity"`
+}
+
+//Match tells whether the entry falls within filter. Statuses are not
+//checked since an entry does not record one.
+func (e *Entry) Match(filter client.FindingFilter) bool {
+	filter.Statuses = nil
+	return filter.Match(&client.Finding{
+		RuleID:         e.RuleID,
+		Level:          e.Severity,
+		Provider:       e.Provider,
+		CloudAccountID: e.CloudAccountID,
+		Region:         e.Region,
+		ObjectID:       e.ObjectID,
+	})
+}
+
+//Baseline is the set of accepted findings
+type Baseline struct {
+	Version  int      `json:"version"`
+	Findings []*Entry `json:"findings"`
+}
+
+//Comparison sorts current findings against a baseline
+type Comparison struct {
+	// New findings are not in the baseline
+	New []*client.Finding
+	// Accepted findings are in the baseline
+	Accepted []*client.Finding
+	// Fixed entries of the baseline are not found anymore
+	Fixed []*Entry
+}
+
+//Fingerprint identifies a finding by its rule and object only, so that it
+//still matches after its status, severity, timestamps or ID change
+func Fingerprint(finding *client.Finding) string {
+	key := strings.Join([]string{
+		finding.RuleID,
+		strings.ToLower(finding.Provider),
+		finding.CloudAccountID,
+		finding.Region,
+		finding.ObjectID,
+	}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:32]
+}
+
+//New returns a baseline accepting findings
+func New(findings []*client.Finding) *Baseline {
+	b := &Baseline{Version: Version, Findings: []*Entry{}}
+	seen := map[string]bool{}
+	for _, finding := range findings {
+		fingerprint := Fingerprint(finding)
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+		b.Findings = append(b.Findings, &Entry{
+			Fingerprint:    fingerprint,
+			RuleID:         finding.RuleID,
+			Provider:       finding.Provider,
+			CloudAccountID: finding.CloudAccountID,
+			Region:         finding.Region,
+			ObjectID:       finding.ObjectID,
+			Severity:       finding.Level,
+		})
+	}
+	b.sort()
+	return b
+}
+
+//Update returns the baseline accepting findings, which were queried with
+//filter, and keeping the entries of b outside filter that the query could
+//not have returned
+func (b *Baseline) Update(findings []*client.Finding, filter client.FindingFilter) *Baseline {
+	updated := New(findings)
+	seen := map[string]bool{}
+	for _, entry := range updated.Findings {
+		seen[entry.Fingerprint] = true
+	}
+	for _, entry := range b.Findings {
+		if !entry.Match(filter) && !seen[entry.Fingerprint] {
+			seen[entry.Fingerprint] = true
+			updated.Findings = append(updated.Findings, entry)
+		}
+	}
+	updated.sort()
+	return updated
+}
+
+//FixedWithin is the fixed entries that match filter, the entries outside it
+//were not queried so they cannot be told fixed
+func (c *Comparison) FixedWithin(filter client.FindingFilter) []*Entry {
+	fixed := []*Entry{}
+	for _, entry := range c.Fixed {
+		if entry.Match(filter) {
+			fixed = append(fixed, entry)
+		}
+	}
+	return fixed
+}
+
+// sort orders the entries the way a reviewer reads them, so that the diff of
+// a committed baseline only shows what changed
+func (b *Baseline) sort() {
+	sort.Slice(b.Findings, func(i, j int) bool {
+		x, y := b.Findings[i], b.Findings[j]
+		if x.RuleID != y.RuleID {
+			return x.RuleID < y.RuleID
+		}
+		if x.CloudAccountID != y.CloudAccountID {
+			return x.CloudAccountID < y.CloudAccountID
+		}
+		if x.Region != y.Region {
+			return x.Region < y.Region
+		}
+		if x.ObjectID != y.ObjectID {
+			return x.ObjectID < y.ObjectID
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+}
+
+//Load reads a baseline file
+func Load(path string) (*Baseline, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Baseline{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %s", path, err)
+	}
+	if b.Version != Version {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", b.Version, path)
+	}
+	return b, nil
+}
+
+//Save writes the baseline to path as indented JSON
+func (b *Baseline) Save(path string) error {
+	b.sort()
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+//Compare sorts findings into new and accepted ones, and returns the entries
+//of the baseline that were not found as fixed
+func (b *Baseline) Compare(findings []*client.Finding) *Comparison {
+	entries := map[string]*Entry{}
+	for _, entry := range b.Findings {
+		entries[entry.Fingerprint] = entry
+	}
+
+	c := &Comparison{}
+	found := map[string]bool{}
+	for _, finding := range findings {
+		fingerprint := Fingerprint(finding)
+		found[fingerprint] = true
+		if entries[fingerprint] != nil {
+			c.Accepted = append(c.Accepted, finding)
+		} else {
+			c.New = append(c.New, finding)
+		}
+	}
+
+	for _, entry := range b.Findings {
+		if !found[entry.Fingerprint] {
+			c.Fixed = append(c.Fixed, entry)
+		}
+	}
+	return c
+}
@@ -0,0 +1,88 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintIgnoresIrrelevantFields(t *testing.T) {
+	a := &client.Finding{ID: "f1", RuleID: "rule", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket", Level: "High", Status: "Open", LastObservedTimestamp: "1"}
+	b := &client.Finding{ID: "f2", RuleID: "rule", Provider: "aws", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket", Level: "Medium", Status: "Suppressed", LastObservedTimestamp: "2"}
+	assert.Equal(t, Fingerprint(a), Fingerprint(b))
+
+	b.ObjectID = "other"
+	assert.NotEqual(t, Fingerprint(a), Fingerprint(b))
+}
+
+func TestCompare(t *testing.T) {
+	accepted := &client.Finding{RuleID: "rule-a", CloudAccountID: "c1", ObjectID: "o1"}
+	fixed := &client.Finding{RuleID: "rule-b", CloudAccountID: "c1", ObjectID: "o2"}
+	b := New([]*client.Finding{fixed, accepted, accepted})
+	assert.Equal(t, 2, len(b.Findings))
+	assert.Equal(t, "rule-a", b.Findings[0].RuleID)
+
+	changed := *accepted
+	changed.Status = "Suppressed"
+	added := &client.Finding{RuleID: "rule-c", CloudAccountID: "c1", ObjectID: "o3"}
+	c := b.Compare([]*client.Finding{&changed, added})
+	assert.Equal(t, []*client.Finding{added}, c.New)
+	assert.Equal(t, []*client.Finding{&changed}, c.Accepted)
+	assert.Equal(t, 1, len(c.Fixed))
+	assert.Equal(t, "rule-b", c.Fixed[0].RuleID)
+}
+
+func TestUpdate(t *testing.T) {
+	b := New([]*client.Finding{
+		{RuleID: "rule-a", CloudAccountID: "c1", ObjectID: "o1"},
+		{RuleID: "rule-b", CloudAccountID: "c2", ObjectID: "o2"},
+	})
+	filter := client.FindingFilter{CloudAccountIDs: []string{"c1"}, Statuses: []string{"Open"}}
+	updated := b.Update([]*client.Finding{{RuleID: "rule-c", CloudAccountID: "c1", ObjectID: "o3"}}, filter)
+	assert.Equal(t, 2, len(updated.Findings))
+	assert.Equal(t, "rule-b", updated.Findings[0].RuleID)
+	assert.Equal(t, "rule-c", updated.Findings[1].RuleID)
+
+	c := b.Compare(nil)
+	assert.Equal(t, 2, len(c.Fixed))
+	assert.Equal(t, "rule-a", c.FixedWithin(filter)[0].RuleID)
+	assert.Equal(t, 1, len(c.FixedWithin(filter)))
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "vss-baseline")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "baseline.json")
+
+	b := New([]*client.Finding{{RuleID: "rule", CloudAccountID: "c1", ObjectID: "o1", Level: "High"}})
+	assert.Nil(t, b.Save(path))
+
+	loaded, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, b, loaded)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"version": 9, "findings": []}`), 0644))
+	_, err = Load(path)
+	assert.NotNil(t, err)
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -14,26 +14,51 @@
 
 package command
 
-import "github.com/CloudCoreo/cli/client"
+import (
+	"context"
+	"io"
+	"net/http"
 
-// Interface for Coreo client for mocking in tests
+	"github.com/CloudCoreo/cli/client"
+)
+
+// Interface for Coreo client for mocking in tests, ctx cancels the underlying API calls
 type Interface interface {
-	ListCloudAccounts() ([]*client.CloudAccount, error)
-	ShowCloudAccountByID(cloudID string) (*client.CloudAccount, error)
-	CreateCloudAccount(input *client.CreateCloudAccountInput) (*client.CloudAccount, error)
-	UpdateCloudAccount(input *client.UpdateCloudAccountInput) (*client.CloudAccount, error)
-	DeleteCloudAccountByID(cloudID string) error
-	ReValidateRole(cloudID string) (*client.RoleReValidationResult, error)
-
-	GetEventStreamConfig(cloudID string) (*client.EventStreamConfig, error)
-	GetEventRemoveConfig(cloudID string) (*client.EventRemoveConfig, error)
-	GetRoleCreationInfo(input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error)
+	ListCloudAccounts(ctx context.Context) ([]*client.CloudAccount, error)
+	QueryCloudAccounts(ctx context.Context, filter client.CloudAccountFilter) ([]*client.CloudAccount, error)
+	ShowCloudAccountByID(ctx context.Context, cloudID string) (*client.CloudAccount, error)
+	CreateCloudAccount(ctx context.Context, input *client.CreateCloudAccountInput) (*client.CloudAccount, error)
+	UpdateCloudAccount(ctx context.Context, input *client.UpdateCloudAccountInput) (*client.CloudAccount, error)
+	DeleteCloudAccountByID(ctx context.Context, cloudID string) error
+	ReValidateRole(ctx context.Context, cloudID string) (*client.RoleReValidationResult, error)
+
+	GetEventStreamConfig(ctx context.Context, cloudID string) (*client.EventStreamConfig, error)
+	GetEventRemoveConfig(ctx context.Context, cloudID string) (*client.EventRemoveConfig, error)
+	GetRoleCreationInfo(ctx context.Context, input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error)
+
+	ListFindings(ctx context.Context, filter client.FindingFilter, limit int) ([]*client.Finding, error)
+
+	ListSuppressions(ctx context.Context) ([]*client.Suppression, error)
+	CreateSuppression(ctx context.Context, suppression *client.Suppression) (*client.Suppression, error)
+	DeleteSuppression(ctx context.Context, suppressionID string) error
+
+	ListRules(ctx context.Context) ([]*client.Rule, error)
+	ShowRuleByID(ctx context.Context, ruleID string) (*client.Rule, error)
+
+	ListNotifications(ctx context.Context) ([]*client.NotificationTarget, error)
+	ShowNotificationByID(ctx context.Context, notificationID string) (*client.NotificationTarget, error)
+	CreateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error)
+	UpdateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error)
+	DeleteNotification(ctx context.Context, notificationID string) error
+	TestNotification(ctx context.Context, notificationID string) (*client.NotificationTestResult, error)
+
+	Call(ctx context.Context, method, path string, header http.Header, body io.Reader) ([]byte, error)
 }
 
-//CloudProvider for adding cloud account
+//CloudProvider for adding cloud account, ctx cancels the underlying cloud SDK calls
 type CloudProvider interface {
-	SetupEventStream(input *client.EventStreamConfig) error
-	CreateNewRole(input *client.RoleCreationInfo) (arn string, externalID string, err error)
-	DeleteRole(roleName string)
-	RemoveEventStream(input *client.EventRemoveConfig) error
+	SetupEventStream(ctx context.Context, input *client.EventStreamConfig) error
+	CreateNewRole(ctx context.Context, input *client.RoleCreationInfo) (arn string, externalID string, err error)
+	DeleteRole(ctx context.Context, roleName string)
+	RemoveEventStream(ctx context.Context, input *client.EventRemoveConfig) error
 }
@@ -15,12 +15,17 @@
 package coreo
 
 import (
+	"context"
+	"io"
+	"net/http"
+
 	"github.com/CloudCoreo/cli/client"
 )
 
 // Client struct
 type Client struct {
 	opts options
+	clt  *client.Client
 }
 
 // NewClient creates a new client.
@@ -37,14 +42,45 @@ func (c *Client) Option(opts ...Option) *Client {
 	return c
 }
 
-//MakeClient make client method
+//MakeClient make client method, the client is reused so that its access token is too
 func (c *Client) MakeClient() (*client.Client, error) {
-	return client.MakeClient(c.opts.refreshToken, c.opts.host)
+	if c.clt != nil {
+		return c.clt, nil
+	}
+
+	var clientOpts []client.Option
+	if c.opts.clientID != "" {
+		clientOpts = append(clientOpts, client.WithClientCredentials(c.opts.clientID, c.opts.clientSecret, c.opts.orgID))
+	}
+	if c.opts.cspURL != "" {
+		clientOpts = append(clientOpts, client.WithCSPURL(c.opts.cspURL))
+	}
+	if c.opts.tokenCache != nil {
+		clientOpts = append(clientOpts, client.WithTokenCache(c.opts.tokenCache))
+	}
+	if c.opts.retry != nil {
+		clientOpts = append(clientOpts, client.WithRetryPolicy(*c.opts.retry))
+	}
+	if c.opts.logger != nil {
+		clientOpts = append(clientOpts, client.WithLogger(c.opts.logger))
+	}
+	if c.opts.transport != nil {
+		clientOpts = append(clientOpts, client.WithTransport(c.opts.transport))
+	}
+	for _, tracer := range c.opts.tracers {
+		clientOpts = append(clientOpts, client.WithTracer(tracer))
+	}
+
+	clt, err := client.MakeClient(c.opts.refreshToken, c.opts.host, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	c.clt = clt
+	return clt, nil
 }
 
 //ListCloudAccounts Get list of cloud accounts
-func (c *Client) ListCloudAccounts() ([]*client.CloudAccount, error) {
-	ctx := NewContext()
+func (c *Client) ListCloudAccounts(ctx context.Context) ([]*client.CloudAccount, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -58,9 +94,18 @@ func (c *Client) ListCloudAccounts() ([]*client.CloudAccount, error) {
 	return cloudAccounts, nil
 }
 
+//QueryCloudAccounts Get list of cloud accounts matching filter
+func (c *Client) QueryCloudAccounts(ctx context.Context, filter client.CloudAccountFilter) ([]*client.CloudAccount, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.QueryCloudAccounts(ctx, filter)
+}
+
 //ShowCloudAccountByID show cloud account by ID
-func (c *Client) ShowCloudAccountByID(cloudID string) (*client.CloudAccount, error) {
-	ctx := NewContext()
+func (c *Client) ShowCloudAccountByID(ctx context.Context, cloudID string) (*client.CloudAccount, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -75,8 +120,7 @@ func (c *Client) ShowCloudAccountByID(cloudID string) (*client.CloudAccount, err
 }
 
 //CreateCloudAccount Create cloud account
-func (c *Client) CreateCloudAccount(input *client.CreateCloudAccountInput) (*client.CloudAccount, error) {
-	ctx := NewContext()
+func (c *Client) CreateCloudAccount(ctx context.Context, input *client.CreateCloudAccountInput) (*client.CloudAccount, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -90,8 +134,7 @@ func (c *Client) CreateCloudAccount(input *client.CreateCloudAccountInput) (*cli
 
 }
 
-func (c *Client) UpdateCloudAccount(input *client.UpdateCloudAccountInput) (*client.CloudAccount, error) {
-	ctx := NewContext()
+func (c *Client) UpdateCloudAccount(ctx context.Context, input *client.UpdateCloudAccountInput) (*client.CloudAccount, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -105,8 +148,7 @@ func (c *Client) UpdateCloudAccount(input *client.UpdateCloudAccountInput) (*cli
 }
 
 //DeleteCloudAccountByID Delete cloud by ID
-func (c *Client) DeleteCloudAccountByID(cloudID string) error {
-	ctx := NewContext()
+func (c *Client) DeleteCloudAccountByID(ctx context.Context, cloudID string) error {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return err
@@ -120,8 +162,7 @@ func (c *Client) DeleteCloudAccountByID(cloudID string) error {
 	return nil
 }
 
-func (c *Client) ReValidateRole(cloudID string) (*client.RoleReValidationResult, error) {
-	ctx := NewContext()
+func (c *Client) ReValidateRole(ctx context.Context, cloudID string) (*client.RoleReValidationResult, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -135,8 +176,7 @@ func (c *Client) ReValidateRole(cloudID string) (*client.RoleReValidationResult,
 }
 
 //GetEventStreamConfig gets event stream setup config
-func (c *Client) GetEventStreamConfig(cloudID string) (*client.EventStreamConfig, error) {
-	ctx := NewContext()
+func (c *Client) GetEventStreamConfig(ctx context.Context, cloudID string) (*client.EventStreamConfig, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -145,8 +185,7 @@ func (c *Client) GetEventStreamConfig(cloudID string) (*client.EventStreamConfig
 	return clt.GetSetupConfig(ctx, cloudID)
 }
 
-func (c *Client) GetEventRemoveConfig(cloudID string) (*client.EventRemoveConfig, error) {
-	ctx := NewContext()
+func (c *Client) GetEventRemoveConfig(ctx context.Context, cloudID string) (*client.EventRemoveConfig, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -155,8 +194,7 @@ func (c *Client) GetEventRemoveConfig(cloudID string) (*client.EventRemoveConfig
 	return clt.GetRemoveConfig(ctx, cloudID)
 }
 
-func (c *Client) GetRoleCreationInfo(input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error) {
-	ctx := NewContext()
+func (c *Client) GetRoleCreationInfo(ctx context.Context, input *client.CreateCloudAccountInput) (*client.RoleCreationInfo, error) {
 	clt, err := c.MakeClient()
 	if err != nil {
 		return nil, err
@@ -164,3 +202,137 @@ func (c *Client) GetRoleCreationInfo(input *client.CreateCloudAccountInput) (*cl
 
 	return clt.GetRoleCreationInfo(ctx, input)
 }
+
+//ListFindings returns the findings matching filter, at most limit of them when limit is positive
+func (c *Client) ListFindings(ctx context.Context, filter client.FindingFilter, limit int) ([]*client.Finding, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetFindings(ctx, filter, limit)
+}
+
+//ListSuppressions returns all suppressions
+func (c *Client) ListSuppressions(ctx context.Context) ([]*client.Suppression, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetSuppressions(ctx)
+}
+
+//CreateSuppression creates a suppression
+func (c *Client) CreateSuppression(ctx context.Context, suppression *client.Suppression) (*client.Suppression, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.CreateSuppression(ctx, suppression)
+}
+
+//DeleteSuppression deletes a suppression by its ID
+func (c *Client) DeleteSuppression(ctx context.Context, suppressionID string) error {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return err
+	}
+
+	return clt.DeleteSuppression(ctx, suppressionID)
+}
+
+//ListRules returns the rule catalog
+func (c *Client) ListRules(ctx context.Context) ([]*client.Rule, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetRules(ctx)
+}
+
+//ShowRuleByID returns a rule of the catalog
+func (c *Client) ShowRuleByID(ctx context.Context, ruleID string) (*client.Rule, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetRuleByID(ctx, ruleID)
+}
+
+//ListNotifications returns all notification targets
+func (c *Client) ListNotifications(ctx context.Context) ([]*client.NotificationTarget, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetNotificationTargets(ctx)
+}
+
+//ShowNotificationByID returns a notification target by its ID
+func (c *Client) ShowNotificationByID(ctx context.Context, notificationID string) (*client.NotificationTarget, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.GetNotificationTargetByID(ctx, notificationID)
+}
+
+//CreateNotification creates a notification target
+func (c *Client) CreateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.CreateNotificationTarget(ctx, target)
+}
+
+//UpdateNotification replaces a notification target
+func (c *Client) UpdateNotification(ctx context.Context, target *client.NotificationTarget) (*client.NotificationTarget, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.UpdateNotificationTarget(ctx, target)
+}
+
+//DeleteNotification deletes a notification target by its ID
+func (c *Client) DeleteNotification(ctx context.Context, notificationID string) error {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return err
+	}
+
+	return clt.DeleteNotificationTarget(ctx, notificationID)
+}
+
+//TestNotification sends a sample notification to a target
+func (c *Client) TestNotification(ctx context.Context, notificationID string) (*client.NotificationTestResult, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return clt.TestNotificationTarget(ctx, notificationID)
+}
+
+//Call sends a request to any API path and returns the undecoded response body
+func (c *Client) Call(ctx context.Context, method, path string, header http.Header, body io.Reader) ([]byte, error) {
+	clt, err := c.MakeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []byte
+	if err := clt.DoWithHeader(ctx, method, path, header, body, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
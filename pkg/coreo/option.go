@@ -15,7 +15,9 @@
 package coreo
 
 import (
-	"context"
+	"net/http"
+
+	"github.com/CloudCoreo/cli/client"
 )
 
 // Option allows specifying various settings configurable by
@@ -25,6 +27,15 @@ type Option func(*options)
 type options struct {
 	host         string
 	refreshToken string
+	cspURL       string
+	tokenCache   client.TokenCache
+	retry        *client.RetryPolicy
+	logger       client.Logger
+	tracers      []client.Tracer
+	transport    http.RoundTripper
+	clientID     string
+	clientSecret string
+	orgID        string
 }
 
 // Host specifies the host address of the Coreo API server.
@@ -41,7 +52,53 @@ func RefreshToken(refreshToken string) Option {
 	}
 }
 
-// NewContext creates a versioned context.
-func NewContext() context.Context {
-	return context.Background()
+//ClientCredentials specifies the CSP OAuth app used instead of the refresh token, orgID may be empty.
+func ClientCredentials(clientID, clientSecret, orgID string) Option {
+	return func(opts *options) {
+		opts.clientID = clientID
+		opts.clientSecret = clientSecret
+		opts.orgID = orgID
+	}
+}
+
+//CSPURL specifies the CSP the refresh token is exchanged with.
+func CSPURL(cspURL string) Option {
+	return func(opts *options) {
+		opts.cspURL = cspURL
+	}
+}
+
+//TokenCache specifies where CSP access tokens are kept between invocations.
+func TokenCache(cache client.TokenCache) Option {
+	return func(opts *options) {
+		opts.tokenCache = cache
+	}
+}
+
+//Retry specifies how failed requests are retried.
+func Retry(policy client.RetryPolicy) Option {
+	return func(opts *options) {
+		opts.retry = &policy
+	}
+}
+
+//Logger specifies where verbose diagnostics such as retries are reported.
+func Logger(logger client.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+//Tracer specifies a function called with every request and response, it may be given more than once.
+func Tracer(tracer client.Tracer) Option {
+	return func(opts *options) {
+		opts.tracers = append(opts.tracers, tracer)
+	}
+}
+
+//Transport specifies what sends the requests instead of the default transport.
+func Transport(rt http.RoundTripper) Option {
+	return func(opts *options) {
+		opts.transport = rt
+	}
 }
@@ -0,0 +1,165 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//Package forward sends finding changes to a SIEM and remembers what was sent
+package forward
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/baseline"
+)
+
+// Actions of an event
+const (
+	ActionNew      = "new"
+	ActionChanged  = "changed"
+	ActionResolved = "resolved"
+)
+
+//CheckpointVersion of the checkpoint file format written
+const CheckpointVersion = 1
+
+//Event is a change of a finding since the last forward
+type Event struct {
+	Time    time.Time       `json:"time"`
+	Action  string          `json:"action"`
+	Finding *client.Finding `json:"finding"`
+}
+
+//Sink receives events
+type Sink interface {
+	// Send delivers all events or fails
+	Send(ctx context.Context, events []*Event) error
+	Close() error
+}
+
+//Checkpoint is the state of the findings when they were last forwarded
+type Checkpoint struct {
+	Version  int                        `json:"version"`
+	Findings map[string]*client.Finding `json:"findings"`
+}
+
+//NewCheckpoint returns the checkpoint of findings
+func NewCheckpoint(findings []*client.Finding) *Checkpoint {
+	c := &Checkpoint{Version: CheckpointVersion, Findings: map[string]*client.Finding{}}
+	for _, finding := range findings {
+		c.Findings[baseline.Fingerprint(finding)] = finding
+	}
+	return c
+}
+
+//LoadCheckpoint reads a checkpoint file, a missing one is empty
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewCheckpoint(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Checkpoint{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %s", path, err)
+	}
+	if c.Version != CheckpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d in %s", c.Version, path)
+	}
+	if c.Findings == nil {
+		c.Findings = map[string]*client.Finding{}
+	}
+	return c, nil
+}
+
+//Save writes the checkpoint, readable only by the current user
+func (c *Checkpoint) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+//Changes returns the events that bring the checkpoint up to findings: new
+//findings, findings whose status or severity changed, and findings that are
+//gone, which are reported resolved
+func (c *Checkpoint) Changes(findings []*client.Finding, now time.Time) []*Event {
+	events := make([]*Event, 0)
+	seen := map[string]bool{}
+	for _, finding := range findings {
+		fingerprint := baseline.Fingerprint(finding)
+		seen[fingerprint] = true
+
+		last := c.Findings[fingerprint]
+		switch {
+		case last == nil:
+			events = append(events, &Event{Time: now, Action: ActionNew, Finding: finding})
+		case last.Status != finding.Status || last.Level != finding.Level:
+			events = append(events, &Event{Time: now, Action: ActionChanged, Finding: finding})
+		}
+	}
+
+	for fingerprint, last := range c.Findings {
+		if !seen[fingerprint] {
+			resolved := *last
+			resolved.Status = "Resolved"
+			events = append(events, &Event{Time: now, Action: ActionResolved, Finding: &resolved})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].Finding, events[j].Finding
+		for _, pair := range [][2]string{
+			{a.CloudAccountID, b.CloudAccountID},
+			{a.RuleID, b.RuleID},
+			{a.Region, b.Region},
+		} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return a.ObjectID < b.ObjectID
+	})
+	return events
+}
+
+// batches splits events into slices of at most size events
+func batches(events []*Event, size int) [][]*Event {
+	if size <= 0 {
+		size = len(events)
+	}
+	var result [][]*Event
+	for len(events) > 0 {
+		n := size
+		if n > len(events) {
+			n = len(events)
+		}
+		result = append(result, events[:n])
+		events = events[n:]
+	}
+	return result
+}
@@ -0,0 +1,298 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package forward
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func testEvents() []*Event {
+	return []*Event{
+		{Time: testTime, Action: ActionNew, Finding: &client.Finding{RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Open"}},
+		{Time: testTime, Action: ActionChanged, Finding: &client.Finding{RuleID: "rule-1", ObjectID: "o2", Level: "Low", Status: "Suppressed"}},
+		{Time: testTime, Action: ActionResolved, Finding: &client.Finding{RuleID: "rule-2", ObjectID: "o3", Level: "High", Status: "Resolved"}},
+	}
+}
+
+func TestCheckpointChanges(t *testing.T) {
+	checkpoint := NewCheckpoint([]*client.Finding{
+		{RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Open"},
+		{RuleID: "rule-1", ObjectID: "o2", Level: "High", Status: "Open"},
+		{RuleID: "rule-2", ObjectID: "o3", Level: "Low", Status: "Open"},
+	})
+
+	events := checkpoint.Changes([]*client.Finding{
+		{RuleID: "rule-1", ObjectID: "o1", Level: "High", Status: "Open"},
+		{RuleID: "rule-1", ObjectID: "o2", Level: "High", Status: "Suppressed"},
+		{RuleID: "rule-3", ObjectID: "o4", Level: "Medium", Status: "Open"},
+	}, testTime)
+
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, ActionChanged, events[0].Action)
+	assert.Equal(t, "o2", events[0].Finding.ObjectID)
+	assert.Equal(t, ActionResolved, events[1].Action)
+	assert.Equal(t, "Resolved", events[1].Finding.Status)
+	assert.Equal(t, "Open", checkpoint.Findings[fingerprintOf(events[1].Finding)].Status, "checkpoint is not changed")
+	assert.Equal(t, ActionNew, events[2].Action)
+	assert.Equal(t, testTime, events[2].Time)
+
+	assert.Empty(t, NewCheckpoint(nil).Changes(nil, testTime))
+}
+
+func fingerprintOf(finding *client.Finding) string {
+	for fingerprint := range NewCheckpoint([]*client.Finding{finding}).Findings {
+		return fingerprint
+	}
+	return ""
+}
+
+func TestCheckpointSaveLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "vss-forward")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "forward", "checkpoint.json")
+
+	c, err := LoadCheckpoint(path)
+	assert.Nil(t, err)
+	assert.Empty(t, c.Findings)
+
+	findings := []*client.Finding{{RuleID: "rule-1", ObjectID: "o1", Status: "Open"}}
+	assert.Nil(t, NewCheckpoint(findings).Save(path))
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	c, err = LoadCheckpoint(path)
+	assert.Nil(t, err)
+	assert.Empty(t, c.Changes(findings, testTime))
+
+	ioutil.WriteFile(path, []byte(`{"version":2}`), 0600)
+	_, err = LoadCheckpoint(path)
+	assert.NotNil(t, err)
+}
+
+func TestBatches(t *testing.T) {
+	events := testEvents()
+	assert.Equal(t, [][]*Event{events[:2], events[2:]}, batches(events, 2))
+	assert.Equal(t, [][]*Event{events}, batches(events, 0))
+	assert.Nil(t, batches(nil, 2))
+}
+
+func TestSplunkSend(t *testing.T) {
+	var paths, auths []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		auths = append(auths, r.Header.Get("Authorization"))
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"text":"Success","code":0}`))
+	}))
+	defer server.Close()
+
+	sink := NewSplunk(server.URL, "token", 2)
+	assert.Nil(t, sink.Send(context.Background(), testEvents()))
+	assert.Nil(t, sink.Close())
+
+	assert.Equal(t, []string{splunkEventPath, splunkEventPath}, paths)
+	assert.Equal(t, []string{"Splunk token", "Splunk token"}, auths)
+	lines := strings.Split(strings.TrimSpace(bodies[0]), "\n")
+	assert.Equal(t, 2, len(lines))
+
+	hec := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &hec))
+	assert.Equal(t, "vss:finding", hec["sourcetype"])
+	assert.Equal(t, float64(testTime.Unix()), hec["time"])
+	assert.Equal(t, "new", hec["event"].(map[string]interface{})["action"])
+}
+
+func TestSplunkKeepsCollectorPath(t *testing.T) {
+	sink := NewSplunk("https://splunk.example.com:8088/services/collector/raw", "token", 1).(*httpSink)
+	assert.Equal(t, "https://splunk.example.com:8088/services/collector/raw", sink.url)
+}
+
+func TestWebhookSend(t *testing.T) {
+	var events []*Event
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-Api-Key")
+		json.NewDecoder(r.Body).Decode(&events)
+	}))
+	defer server.Close()
+
+	sink := NewWebhook(server.URL, http.Header{"X-Api-Key": {"key"}}, 100)
+	assert.Nil(t, sink.Send(context.Background(), testEvents()))
+	assert.Equal(t, "key", apiKey)
+	assert.Equal(t, testEvents(), events)
+}
+
+func TestWebhookRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, nil, 100).Send(context.Background(), testEvents())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden invalid token")
+}
+
+// receiveSyslog reads the octet counted messages of the first connection to
+// listener, and sends them once the connection is closed
+func receiveSyslog(listener net.Listener) chan []string {
+	received := make(chan []string)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+
+		var messages []string
+		r := bufio.NewReader(conn)
+		for {
+			prefix, err := r.ReadString(' ')
+			if err != nil {
+				break
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(prefix))
+			msg := make([]byte, n)
+			if _, err := r.Read(msg); err != nil {
+				break
+			}
+			messages = append(messages, string(msg))
+		}
+		received <- messages
+	}()
+	return received
+}
+
+func TestSyslogTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	received := receiveSyslog(listener)
+
+	sink, err := NewSyslog(SyslogTCP, listener.Addr().String(), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, sink.Send(context.Background(), testEvents()))
+	assert.Nil(t, sink.Close())
+
+	messages := <-received
+	assert.Equal(t, 3, len(messages))
+	assert.True(t, strings.HasPrefix(messages[0], "<131>1 2026-10-18T12:00:00Z "), messages[0])
+	assert.True(t, strings.HasPrefix(messages[1], "<133>1 "), messages[1])
+	assert.True(t, strings.HasPrefix(messages[2], "<134>1 "), messages[2])
+	assert.Contains(t, messages[0], " vss - finding - {")
+}
+
+func TestSyslogTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: server.TLS.Certificates})
+	assert.Nil(t, err)
+	defer listener.Close()
+	received := receiveSyslog(listener)
+
+	roots := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	sink, err := NewSyslog(SyslogTLS, listener.Addr().String(), &tls.Config{RootCAs: roots})
+	assert.Nil(t, err)
+	assert.Nil(t, sink.Send(context.Background(), testEvents()))
+	assert.Nil(t, sink.Close())
+
+	messages := <-received
+	assert.Equal(t, 3, len(messages))
+	assert.True(t, strings.HasPrefix(messages[0], "<131>1 2026-10-18T12:00:00Z "), messages[0])
+}
+
+func TestSyslogTLSUntrusted(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: server.TLS.Certificates})
+	assert.Nil(t, err)
+	defer listener.Close()
+	go receiveSyslog(listener)
+
+	sink, err := NewSyslog(SyslogTLS, listener.Addr().String(), nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, sink.Send(context.Background(), testEvents()))
+}
+
+func TestSyslogTLSCancelledHandshake(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		// accept but never answer the handshake
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	sink, err := NewSyslog(SyslogTLS, listener.Addr().String(), nil)
+	assert.Nil(t, err)
+	start := time.Now()
+	assert.Equal(t, context.Canceled, sink.Send(ctx, testEvents()))
+	assert.True(t, time.Since(start) < 2*time.Second)
+}
+
+func TestSyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	sink, err := NewSyslog(SyslogUDP, conn.LocalAddr().String(), nil)
+	assert.Nil(t, err)
+	defer sink.Close()
+	assert.Nil(t, sink.Send(context.Background(), testEvents()[:1]))
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "<131>1 "))
+
+	event := &Event{}
+	assert.Nil(t, json.Unmarshal(buf[strings.Index(string(buf[:n]), "{"):n], event))
+	assert.Equal(t, "o1", event.Finding.ObjectID)
+}
+
+func TestSyslogUnsupportedProtocol(t *testing.T) {
+	_, err := NewSyslog("http", "localhost:514", nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// splunkEventPath is where the HTTP Event Collector takes JSON events
+const splunkEventPath = "/services/collector/event"
+
+// maxErrorBody is how much of a rejected response is quoted in the error
+const maxErrorBody = 512
+
+// httpSink posts batches of events
+type httpSink struct {
+	url       string
+	header    http.Header
+	batchSize int
+	encode    func(batch []*Event) ([]byte, error)
+}
+
+//NewSplunk returns a sink for the Splunk HTTP Event Collector at url, e.g.
+//https://splunk.example.com:8088, authenticated with an HEC token
+func NewSplunk(collector, token string, batchSize int) Sink {
+	if u, err := url.Parse(collector); err == nil && (u.Path == "" || u.Path == "/") {
+		u.Path = splunkEventPath
+		collector = u.String()
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Splunk "+token)
+	return &httpSink{url: collector, header: header, batchSize: batchSize, encode: encodeSplunk}
+}
+
+//NewWebhook returns a sink posting JSON arrays of events to endpoint with header
+func NewWebhook(endpoint string, header http.Header, batchSize int) Sink {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &httpSink{url: endpoint, header: header, batchSize: batchSize, encode: encodeWebhook}
+}
+
+// encodeSplunk concatenates one HEC event object per event
+func encodeSplunk(batch []*Event) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, event := range batch {
+		err := encoder.Encode(map[string]interface{}{
+			"time":       float64(event.Time.UnixNano()) / 1e9,
+			"source":     "vss",
+			"sourcetype": "vss:finding",
+			"event":      event,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeWebhook(batch []*Event) ([]byte, error) {
+	return json.Marshal(batch)
+}
+
+func (s *httpSink) Send(ctx context.Context, events []*Event) error {
+	for _, batch := range batches(events, s.batchSize) {
+		body, err := s.encode(batch)
+		if err != nil {
+			return err
+		}
+		if err := s.post(ctx, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *httpSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range s.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// The default client sends through http.DefaultTransport, which carries
+	// the proxy and TLS settings of the profile.
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("%s rejected the events: %s %s", s.url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Syslog protocols
+const (
+	SyslogTCP = "tcp"
+	SyslogTLS = "tls"
+	SyslogUDP = "udp"
+)
+
+// syslogFacility is local0, severities are added to it
+const syslogFacility = 16
+
+// syslogSink writes RFC 5424 messages, framed by octet counting (RFC 6587)
+// over TCP and TLS and one per datagram over UDP
+type syslogSink struct {
+	protocol  string
+	address   string
+	tlsConfig *tls.Config
+	hostname  string
+	conn      net.Conn
+}
+
+//NewSyslog returns a sink for the syslog receiver at address, over protocol
+//tcp, tls or udp. tlsConfig is used for tls, nil verifies with the system roots.
+func NewSyslog(protocol, address string, tlsConfig *tls.Config) (Sink, error) {
+	switch protocol {
+	case SyslogTCP, SyslogTLS, SyslogUDP:
+	default:
+		return nil, fmt.Errorf("unsupported syslog protocol %q, use tcp, tls or udp", protocol)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{protocol: protocol, address: address, tlsConfig: tlsConfig, hostname: hostname}, nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	switch s.protocol {
+	case SyslogUDP:
+		return dialer.DialContext(ctx, "udp", s.address)
+	case SyslogTLS:
+		config := &tls.Config{}
+		if s.tlsConfig != nil {
+			config = s.tlsConfig.Clone()
+		}
+		if config.ServerName == "" {
+			config.ServerName, _, _ = net.SplitHostPort(s.address)
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", s.address)
+		if err != nil {
+			return nil, err
+		}
+		return handshake(ctx, tls.Client(conn, config), dialer.Timeout)
+	default:
+		return dialer.DialContext(ctx, "tcp", s.address)
+	}
+}
+
+// handshake runs the TLS handshake of conn within timeout, and aborts it
+// when ctx is done first
+func handshake(ctx context.Context, conn *tls.Conn, timeout time.Duration) (net.Conn, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Unix(1, 0))
+		case <-stop:
+		}
+	}()
+
+	err := conn.Handshake()
+	close(stop)
+	<-stopped
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// syslogSeverity maps the severity of a finding to a syslog severity,
+// resolved findings are informational
+func syslogSeverity(event *Event) int {
+	if event.Action == ActionResolved {
+		return 6
+	}
+	switch strings.ToLower(event.Finding.Level) {
+	case "high":
+		return 3
+	case "medium":
+		return 4
+	default:
+		return 5
+	}
+}
+
+// message formats event as an RFC 5424 message with a JSON body
+func (s *syslogSink) message(event *Event) ([]byte, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("<%d>1 %s %s vss - finding - ",
+		syslogFacility*8+syslogSeverity(event), event.Time.UTC().Format(time.RFC3339), s.hostname)
+	return append([]byte(header), body...), nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, events []*Event) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg, err := s.message(event)
+		if err != nil {
+			return err
+		}
+		if s.protocol != SyslogUDP {
+			msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+		}
+		if _, err := s.conn.Write(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
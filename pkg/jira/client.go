@@ -0,0 +1,201 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// searchPageSize is how many issues are fetched per search request
+const searchPageSize = 100
+
+// maxErrorBody is how much of a rejected response is quoted in the error
+const maxErrorBody = 512
+
+//Issue is a Jira issue created for a finding
+type Issue struct {
+	Key         string
+	FindingID   string
+	Status      string
+	Done        bool
+	LastComment string
+}
+
+//Client talks to the Jira REST API version 2
+type Client struct {
+	url   string
+	user  string
+	token string
+}
+
+//NewClient returns a client for the Jira site at url. With a user the token
+//is an API token sent with basic auth, without it a personal access token.
+func NewClient(url, user, token string) *Client {
+	return &Client{url: strings.TrimRight(url, "/"), user: user, token: token}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	// The default client sends through http.DefaultTransport, which carries
+	// the proxy and TLS settings of the profile.
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("jira %s %s: %s %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+type searchResult struct {
+	StartAt int `json:"startAt"`
+	Total   int `json:"total"`
+	Issues  []struct {
+		Key    string                     `json:"key"`
+		Fields map[string]json.RawMessage `json:"fields"`
+	} `json:"issues"`
+}
+
+type status struct {
+	Name           string `json:"name"`
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+//Issues returns the issues created for findings, keyed by finding ID
+func (c *Client) Issues(ctx context.Context, m *Mapping) (map[string]*Issue, error) {
+	issues := map[string]*Issue{}
+	for startAt := 0; ; {
+		result := &searchResult{}
+		err := c.do(ctx, "POST", "/rest/api/2/search", map[string]interface{}{
+			"jql":        m.jql(),
+			"startAt":    startAt,
+			"maxResults": searchPageSize,
+			"fields":     []string{"status", "comment", m.FindingIDField},
+		}, result)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, found := range result.Issues {
+			var findingID string
+			if json.Unmarshal(found.Fields[m.FindingIDField], &findingID) != nil || findingID == "" {
+				continue
+			}
+			s := status{}
+			json.Unmarshal(found.Fields["status"], &s)
+			comments := struct {
+				Comments []struct {
+					Body string `json:"body"`
+				} `json:"comments"`
+			}{}
+			json.Unmarshal(found.Fields["comment"], &comments)
+
+			issue := &Issue{Key: found.Key, FindingID: findingID, Status: s.Name, Done: s.StatusCategory.Key == "done"}
+			if n := len(comments.Comments); n > 0 {
+				issue.LastComment = comments.Comments[n-1].Body
+			}
+			// A finding with several issues is tracked by its open one
+			if last, ok := issues[findingID]; !ok || last.Done && !issue.Done {
+				issues[findingID] = issue
+			}
+		}
+
+		startAt += len(result.Issues)
+		if len(result.Issues) == 0 || startAt >= result.Total {
+			return issues, nil
+		}
+	}
+}
+
+//CreateIssue creates an issue with fields and returns its key
+func (c *Client) CreateIssue(ctx context.Context, fields map[string]interface{}) (string, error) {
+	created := struct {
+		Key string `json:"key"`
+	}{}
+	if err := c.do(ctx, "POST", "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &created); err != nil {
+		return "", err
+	}
+	return created.Key, nil
+}
+
+//AddComment comments on the issue key
+func (c *Client) AddComment(ctx context.Context, key, body string) error {
+	return c.do(ctx, "POST", "/rest/api/2/issue/"+key+"/comment", map[string]string{"body": body}, nil)
+}
+
+//Transition moves the issue key through the transition named name, or
+//leading to the status named name
+func (c *Client) Transition(ctx context.Context, key, name string) error {
+	result := struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   status `json:"to"`
+		} `json:"transitions"`
+	}{}
+	path := "/rest/api/2/issue/" + key + "/transitions"
+	if err := c.do(ctx, "GET", path, nil, &result); err != nil {
+		return err
+	}
+
+	var available []string
+	for _, t := range result.Transitions {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.To.Name, name) {
+			return c.do(ctx, "POST", path, map[string]interface{}{"transition": map[string]string{"id": t.ID}}, nil)
+		}
+		available = append(available, t.Name)
+	}
+	return fmt.Errorf("issue %s has no transition %q, available: %s", key, name, strings.Join(available, ", "))
+}
@@ -0,0 +1,298 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMapping = `url: %s
+project: SEC
+issueType: Bug
+component: Cloud Security
+labels: [vss]
+findingIdField: customfield_10042
+resolveTransition: Done
+reopenTransition: Reopen
+severities:
+  High: {priority: Highest}
+  Low: {project: SECLOW, component: Backlog, priority: Low}
+`
+
+func writeMapping(t *testing.T, body string) (string, func()) {
+	dir, err := ioutil.TempDir("", "vss-jira")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "vss-jira.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(body), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// fakeJira serves the part of the Jira API the sync uses
+type fakeJira struct {
+	sync.Mutex
+	issues      []map[string]interface{}
+	created     []map[string]interface{}
+	comments    map[string][]string
+	transitions map[string]string
+	searches    int
+}
+
+func newFakeJira() *fakeJira {
+	return &fakeJira{comments: map[string][]string{}, transitions: map[string]string{}}
+}
+
+func (f *fakeJira) addIssue(key, findingID, category string) {
+	f.issues = append(f.issues, map[string]interface{}{
+		"key": key,
+		"fields": map[string]interface{}{
+			"status":            map[string]interface{}{"name": category, "statusCategory": map[string]string{"key": category}},
+			"customfield_10042": findingID,
+		},
+	})
+}
+
+// commentsOn returns the comments on the issue key as the search returns them
+func (f *fakeJira) commentsOn(key string) []map[string]string {
+	comments := []map[string]string{}
+	for _, body := range f.comments[key] {
+		comments = append(comments, map[string]string{"body": body})
+	}
+	return comments
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body := map[string]interface{}{}
+	json.NewDecoder(r.Body).Decode(&body)
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/2/"), "/")
+	switch {
+	case r.URL.Path == "/rest/api/2/search":
+		f.searches++
+		start := int(body["startAt"].(float64))
+		end := start + 2
+		if end > len(f.issues) {
+			end = len(f.issues)
+		}
+		issues := make([]map[string]interface{}, 0, end-start)
+		for _, issue := range f.issues[start:end] {
+			fields := map[string]interface{}{"comment": map[string]interface{}{"comments": f.commentsOn(issue["key"].(string))}}
+			for k, v := range issue["fields"].(map[string]interface{}) {
+				fields[k] = v
+			}
+			issues = append(issues, map[string]interface{}{"key": issue["key"], "fields": fields})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"startAt": start, "total": len(f.issues), "issues": issues})
+	case r.URL.Path == "/rest/api/2/issue":
+		f.created = append(f.created, body["fields"].(map[string]interface{}))
+		fmt.Fprintf(w, `{"key":"SEC-%d"}`, 100+len(f.created))
+	case len(parts) == 3 && parts[2] == "comment":
+		f.comments[parts[1]] = append(f.comments[parts[1]], body["body"].(string))
+		w.WriteHeader(http.StatusCreated)
+	case len(parts) == 3 && parts[2] == "transitions" && r.Method == "GET":
+		fmt.Fprint(w, `{"transitions":[{"id":"11","name":"Start","to":{"name":"In Progress"}},{"id":"31","name":"Close","to":{"name":"Done"}},{"id":"41","name":"Reopen","to":{"name":"To Do"}}]}`)
+	case len(parts) == 3 && parts[2] == "transitions":
+		f.transitions[parts[1]] = body["transition"].(map[string]interface{})["id"].(string)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	path, cleanup := writeMapping(t, fmt.Sprintf(testMapping, "https://example.atlassian.net"))
+	defer cleanup()
+
+	m, err := LoadMapping(path)
+	assert.Nil(t, err)
+	assert.Equal(t, Target{Project: "SEC", Component: "Cloud Security", Priority: "Highest"}, m.Target("high"))
+	assert.Equal(t, Target{Project: "SEC", Component: "Cloud Security"}, m.Target("Medium"))
+	assert.Equal(t, Target{Project: "SECLOW", Component: "Backlog", Priority: "Low"}, m.Target("Low"))
+	assert.Equal(t, []string{"SEC", "SECLOW"}, m.Projects())
+	assert.Equal(t, `project in ("SEC", "SECLOW") AND cf[10042] is not EMPTY`, m.jql())
+}
+
+func TestLoadMappingInvalid(t *testing.T) {
+	tests := []struct {
+		desc, body, err string
+	}{
+		{desc: "missing fields", body: "project: SEC\n", err: "url, issueType, findingIdField, resolveTransition required"},
+		{desc: "unknown key", body: "projects: SEC\n", err: "projects"},
+		{desc: "bad field", body: "url: u\nproject: SEC\nissueType: Bug\nfindingIdField: Finding ID\nresolveTransition: Done\n", err: "not a custom field"},
+		{desc: "bad severity", body: "url: u\nproject: SEC\nissueType: Bug\nfindingIdField: customfield_1\nresolveTransition: Done\nseverities:\n  Critical: {priority: Highest}\n", err: `unknown severity "Critical"`},
+	}
+
+	for _, tt := range tests {
+		path, cleanup := writeMapping(t, tt.body)
+		_, err := LoadMapping(path)
+		if assert.NotNil(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.err, tt.desc)
+		}
+		cleanup()
+	}
+}
+
+func TestPlan(t *testing.T) {
+	findings := []*client.Finding{
+		{ID: "f1", RuleID: "rule-1", ObjectID: "o1", Status: "Open"},
+		{ID: "f2", RuleID: "rule-1", ObjectID: "o2", Status: "Open"},
+		{ID: "f3", RuleID: "rule-2", ObjectID: "o3", Status: "Open"},
+		{ID: "f4", RuleID: "rule-2", ObjectID: "o4", Status: "Resolved"},
+		{ID: "f5", RuleID: "rule-2", ObjectID: "o5", Status: "Resolved"},
+		{ID: "f6", RuleID: "rule-2", ObjectID: "o6", Status: "Suppressed"},
+		{ID: "f7", RuleID: "rule-0", ObjectID: "o7", Status: "Resolved"},
+		{ID: "f8", RuleID: "rule-3", ObjectID: "o8", Status: "Open"},
+	}
+	issues := map[string]*Issue{
+		"f2": {Key: "SEC-2", FindingID: "f2"},
+		"f3": {Key: "SEC-3", FindingID: "f3", Done: true},
+		"f4": {Key: "SEC-4", FindingID: "f4"},
+		"f5": {Key: "SEC-5", FindingID: "f5", Done: true},
+		"f6": {Key: "SEC-6", FindingID: "f6"},
+		"f8": {Key: "SEC-8", FindingID: "f8", Done: true, LastComment: "Finding f8 is open again, last observed now."},
+	}
+
+	actions := Plan(findings, issues)
+	assert.Equal(t, 3, len(actions))
+	assert.Equal(t, &Action{Action: ActionCreate, FindingID: "f1", RuleID: "rule-1", ObjectID: "o1", Finding: findings[0]}, actions[0])
+	assert.Equal(t, ActionReoccur, actions[1].Action)
+	assert.Equal(t, "SEC-3", actions[1].IssueKey)
+	assert.Equal(t, ActionResolve, actions[2].Action)
+	assert.Equal(t, "SEC-4", actions[2].IssueKey)
+}
+
+func TestSync(t *testing.T) {
+	jira := newFakeJira()
+	jira.addIssue("SEC-1", "f2", "done")
+	jira.addIssue("SEC-2", "f3", "indeterminate")
+	jira.addIssue("SEC-3", "f3", "done")
+	server := httptest.NewServer(jira)
+	defer server.Close()
+
+	path, cleanup := writeMapping(t, fmt.Sprintf(testMapping, server.URL+"/"))
+	defer cleanup()
+	m, err := LoadMapping(path)
+	assert.Nil(t, err)
+
+	c := NewClient(m.URL, "me@example.com", "token")
+	issues, err := c.Issues(context.Background(), m)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, jira.searches, "issues are fetched page by page")
+	assert.Equal(t, map[string]*Issue{
+		"f2": {Key: "SEC-1", FindingID: "f2", Status: "done", Done: true},
+		"f3": {Key: "SEC-2", FindingID: "f3", Status: "indeterminate"},
+	}, issues)
+
+	findings := []*client.Finding{
+		{ID: "f1", RuleID: "rule-1", RuleName: "Public bucket", ObjectID: "o1", Level: "Low", Status: "Open", CloudAccountID: "c1"},
+		{ID: "f2", RuleID: "rule-1", ObjectID: "o2", Status: "Open", LastObservedTimestamp: "2026-10-18T10:00:00Z"},
+		{ID: "f3", RuleID: "rule-2", ObjectID: "o3", Status: "Resolved"},
+	}
+	actions := Plan(findings, issues)
+	done, err := Apply(context.Background(), c, m, actions)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, done)
+	assert.Equal(t, "SEC-101", actions[0].IssueKey)
+
+	created := jira.created[0]
+	assert.Equal(t, "Public bucket: o1", created["summary"])
+	assert.Equal(t, "f1", created["customfield_10042"])
+	assert.Equal(t, map[string]interface{}{"key": "SECLOW"}, created["project"])
+	assert.Equal(t, map[string]interface{}{"name": "Low"}, created["priority"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Backlog"}}, created["components"])
+	assert.Equal(t, []interface{}{"vss"}, created["labels"])
+	assert.Contains(t, created["description"], "*Cloud account:* c1\n")
+
+	assert.Equal(t, []string{"Finding f2 is open again, last observed 2026-10-18T10:00:00Z."}, jira.comments["SEC-1"])
+	assert.Equal(t, "41", jira.transitions["SEC-1"])
+	assert.Equal(t, []string{"Finding f3 was resolved."}, jira.comments["SEC-2"])
+	assert.Equal(t, "31", jira.transitions["SEC-2"])
+}
+
+func TestSyncReoccurWithoutReopenTransition(t *testing.T) {
+	jira := newFakeJira()
+	jira.addIssue("SEC-1", "f1", "done")
+	server := httptest.NewServer(jira)
+	defer server.Close()
+
+	m := &Mapping{URL: server.URL, Project: "SEC", IssueType: "Bug", FindingIDField: "customfield_10042", ResolveTransition: "Close"}
+	c := NewClient(m.URL, "me@example.com", "token")
+	findings := []*client.Finding{{ID: "f1", RuleID: "rule-1", ObjectID: "o1", Status: "Open", LastObservedTimestamp: "2026-10-18T10:00:00Z"}}
+
+	for run := 1; run <= 2; run++ {
+		issues, err := c.Issues(context.Background(), m)
+		assert.Nil(t, err)
+		if run == 2 {
+			findings[0].LastObservedTimestamp = "2026-10-19T10:00:00Z"
+		}
+		_, err = Apply(context.Background(), c, m, Plan(findings, issues))
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, []string{"Finding f1 is open again, last observed 2026-10-18T10:00:00Z."}, jira.comments["SEC-1"], "the closed issue is commented on once")
+	assert.Empty(t, jira.transitions)
+}
+
+func TestIssueFieldsLongSummary(t *testing.T) {
+	m := &Mapping{Project: "SEC", IssueType: "Bug", FindingIDField: "customfield_10042"}
+	object := strings.Repeat("é", 300)
+	summary := issueFields(m, &client.Finding{ID: "f1", RuleID: "rule-1", ObjectID: object})["summary"].(string)
+	assert.True(t, utf8.ValidString(summary))
+	assert.Equal(t, maxSummary, utf8.RuneCountInString(summary))
+	assert.Equal(t, "rule-1: "+strings.Repeat("é", maxSummary-len("rule-1: ")-3)+"...", summary)
+
+	summary = issueFields(m, &client.Finding{ID: "f1", RuleID: "rule-1", ObjectID: strings.Repeat("é", 200)})["summary"].(string)
+	assert.Equal(t, "rule-1: "+strings.Repeat("é", 200), summary, "a summary of 208 characters but more bytes is kept")
+}
+
+func TestSyncErrors(t *testing.T) {
+	server := httptest.NewServer(newFakeJira())
+	defer server.Close()
+
+	m := &Mapping{Project: "SEC", FindingIDField: "customfield_10042", ResolveTransition: "Resolve"}
+	_, err := NewClient(server.URL, "me@example.com", "wrong").Issues(context.Background(), m)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "401 Unauthorized unauthorized")
+	}
+
+	actions := []*Action{
+		{Action: ActionResolve, IssueKey: "SEC-1", FindingID: "f1"},
+		{Action: ActionResolve, IssueKey: "SEC-2", FindingID: "f2"},
+	}
+	done, err := Apply(context.Background(), NewClient(server.URL, "me@example.com", "token"), m, actions)
+	assert.Equal(t, 0, done)
+	assert.EqualError(t, err, `issue SEC-1 has no transition "Resolve", available: Start, Close, Reopen`)
+}
@@ -0,0 +1,144 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+//Package jira keeps Jira issues in step with findings
+package jira
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var customFieldPattern = regexp.MustCompile(`^customfield_(\d+)$`)
+
+//Target is where the issues of findings of a severity are created
+type Target struct {
+	Project   string `yaml:"project"`
+	Component string `yaml:"component"`
+	Priority  string `yaml:"priority"`
+}
+
+//Mapping describes how findings map to Jira issues
+type Mapping struct {
+	URL       string   `yaml:"url"`
+	Project   string   `yaml:"project"`
+	IssueType string   `yaml:"issueType"`
+	Component string   `yaml:"component"`
+	Labels    []string `yaml:"labels"`
+
+	// FindingIDField is the custom field, e.g. customfield_10042, holding the
+	// ID of the finding an issue was created for
+	FindingIDField string `yaml:"findingIdField"`
+
+	// ResolveTransition closes issues of resolved findings, ReopenTransition
+	// reopens issues of findings that reoccur and is optional
+	ResolveTransition string `yaml:"resolveTransition"`
+	ReopenTransition  string `yaml:"reopenTransition"`
+
+	// Severities overrides project, component and priority by severity
+	Severities map[string]*Target `yaml:"severities"`
+}
+
+//LoadMapping reads and validates a mapping file
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapping{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, fmt.Errorf("invalid mapping %s: %s", path, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping %s: %s", path, err)
+	}
+	return m, nil
+}
+
+func (m *Mapping) validate() error {
+	var missing []string
+	for _, field := range []struct{ name, value string }{
+		{"url", m.URL},
+		{"project", m.Project},
+		{"issueType", m.IssueType},
+		{"findingIdField", m.FindingIDField},
+		{"resolveTransition", m.ResolveTransition},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s required", strings.Join(missing, ", "))
+	}
+
+	if !customFieldPattern.MatchString(m.FindingIDField) {
+		return fmt.Errorf("findingIdField %q is not a custom field like customfield_10042", m.FindingIDField)
+	}
+	for severity := range m.Severities {
+		switch strings.ToLower(severity) {
+		case "high", "medium", "low":
+		default:
+			return fmt.Errorf("unknown severity %q, use High, Medium or Low", severity)
+		}
+	}
+	return nil
+}
+
+//Target returns where issues of findings of severity level go
+func (m *Mapping) Target(level string) Target {
+	t := Target{Project: m.Project, Component: m.Component}
+	for severity, override := range m.Severities {
+		if override == nil || !strings.EqualFold(severity, level) {
+			continue
+		}
+		if override.Project != "" {
+			t.Project = override.Project
+		}
+		if override.Component != "" {
+			t.Component = override.Component
+		}
+		t.Priority = override.Priority
+	}
+	return t
+}
+
+//Projects returns every project issues can be created in
+func (m *Mapping) Projects() []string {
+	projects := []string{m.Project}
+	seen := map[string]bool{m.Project: true}
+	for _, severity := range []string{"High", "Medium", "Low"} {
+		if p := m.Target(severity).Project; !seen[p] {
+			seen[p] = true
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+// jql finds the issues created for findings in the mapped projects
+func (m *Mapping) jql() string {
+	quoted := make([]string, len(m.Projects()))
+	for i, project := range m.Projects() {
+		quoted[i] = fmt.Sprintf("%q", project)
+	}
+	id := customFieldPattern.FindStringSubmatch(m.FindingIDField)[1]
+	return fmt.Sprintf("project in (%s) AND cf[%s] is not EMPTY", strings.Join(quoted, ", "), id)
+}
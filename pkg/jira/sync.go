@@ -0,0 +1,178 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package jira
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+// Kinds of actions
+const (
+	ActionCreate  = "create"
+	ActionReoccur = "reoccur"
+	ActionResolve = "resolve"
+)
+
+// maxSummary is the most characters Jira accepts in a summary
+const maxSummary = 255
+
+//Action is a change to Jira that brings an issue in step with its finding
+type Action struct {
+	Action    string          `json:"action"`
+	IssueKey  string          `json:"issueKey,omitempty"`
+	FindingID string          `json:"findingId"`
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	ObjectID  string          `json:"objectId"`
+	Finding   *client.Finding `json:"-"`
+}
+
+//Plan returns the actions that bring issues in step with findings: an issue
+//for each open finding without one, a comment on the closed issue of an open
+//finding unless it is the last comment already, and closing the open issue of
+//a resolved finding. Suppressed findings are left alone.
+func Plan(findings []*client.Finding, issues map[string]*Issue) []*Action {
+	actions := make([]*Action, 0)
+	for _, finding := range findings {
+		issue := issues[finding.ID]
+		action := ""
+		switch {
+		case finding.Status == "Open" && issue == nil:
+			action = ActionCreate
+		case finding.Status == "Open" && issue.Done && !strings.HasPrefix(issue.LastComment, reoccurPrefix(finding)):
+			action = ActionReoccur
+		case finding.Status == "Resolved" && issue != nil && !issue.Done:
+			action = ActionResolve
+		default:
+			continue
+		}
+
+		a := &Action{
+			Action:    action,
+			FindingID: finding.ID,
+			RuleID:    finding.RuleID,
+			Level:     finding.Level,
+			ObjectID:  finding.ObjectID,
+			Finding:   finding,
+		}
+		if issue != nil {
+			a.IssueKey = issue.Key
+		}
+		actions = append(actions, a)
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].RuleID != actions[j].RuleID {
+			return actions[i].RuleID < actions[j].RuleID
+		}
+		return actions[i].ObjectID < actions[j].ObjectID
+	})
+	return actions
+}
+
+//Apply carries out actions in order and returns how many succeeded. Creating
+//an issue sets the key of its action.
+func Apply(ctx context.Context, c *Client, m *Mapping, actions []*Action) (int, error) {
+	for i, a := range actions {
+		var err error
+		switch a.Action {
+		case ActionCreate:
+			a.IssueKey, err = c.CreateIssue(ctx, issueFields(m, a.Finding))
+		case ActionReoccur:
+			err = c.AddComment(ctx, a.IssueKey, fmt.Sprintf("%s, last observed %s.", reoccurPrefix(a.Finding), observed(a.Finding)))
+			if err == nil && m.ReopenTransition != "" {
+				err = c.Transition(ctx, a.IssueKey, m.ReopenTransition)
+			}
+		case ActionResolve:
+			err = c.AddComment(ctx, a.IssueKey, fmt.Sprintf("Finding %s was resolved.", a.FindingID))
+			if err == nil {
+				err = c.Transition(ctx, a.IssueKey, m.ResolveTransition)
+			}
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(actions), nil
+}
+
+// reoccurPrefix starts the comment on the closed issue of a finding that is
+// open again, whatever the time it was last observed
+func reoccurPrefix(finding *client.Finding) string {
+	return fmt.Sprintf("Finding %s is open again", finding.ID)
+}
+
+func observed(finding *client.Finding) string {
+	if finding.LastObservedTimestamp == "" {
+		return "now"
+	}
+	return finding.LastObservedTimestamp
+}
+
+// issueFields returns the fields of a new issue for finding
+func issueFields(m *Mapping, finding *client.Finding) map[string]interface{} {
+	target := m.Target(finding.Level)
+
+	rule := finding.RuleName
+	if rule == "" {
+		rule = finding.RuleID
+	}
+	summary := fmt.Sprintf("%s: %s", rule, finding.ObjectID)
+	if runes := []rune(summary); len(runes) > maxSummary {
+		summary = string(runes[:maxSummary-3]) + "..."
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "VMware Secure State found a violation of rule %s.\n\n", rule)
+	for _, row := range [][2]string{
+		{"Rule ID", finding.RuleID},
+		{"Severity", finding.Level},
+		{"Provider", finding.Provider},
+		{"Cloud account", finding.CloudAccountID},
+		{"Region", finding.Region},
+		{"Object", finding.ObjectID},
+		{"Object path", finding.ObjectPath},
+		{"First observed", finding.FirstObservedTimestamp},
+		{"Finding ID", finding.ID},
+	} {
+		if row[1] != "" {
+			fmt.Fprintf(&description, "*%s:* %s\n", row[0], row[1])
+		}
+	}
+
+	fields := map[string]interface{}{
+		"project":        map[string]string{"key": target.Project},
+		"issuetype":      map[string]string{"name": m.IssueType},
+		"summary":        summary,
+		"description":    description.String(),
+		m.FindingIDField: finding.ID,
+	}
+	if len(m.Labels) > 0 {
+		fields["labels"] = m.Labels
+	}
+	if target.Component != "" {
+		fields["components"] = []map[string]string{{"name": target.Component}}
+	}
+	if target.Priority != "" {
+		fields["priority"] = map[string]string{"name": target.Priority}
+	}
+	return fields
+}
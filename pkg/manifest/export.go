@@ -0,0 +1,106 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+//Export returns a manifest of cloud accounts that cloud import can add back,
+//sorted by name. Secrets are replaced by ${NAME} placeholders of environment
+//variables and fields set by the server, such as the ID, validation status
+//and the ID of the role, are left out.
+func Export(clouds []*client.CloudAccount) *Manifest {
+	sorted := append([]*client.CloudAccount(nil), clouds...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	m := &Manifest{CloudAccounts: make([]*CloudAccount, 0, len(sorted))}
+	used := map[string]bool{}
+	placeholder := func(name, secret string) string {
+		variable := "VSS_" + envName(name) + "_" + secret
+		for i := 2; used[variable]; i++ {
+			variable = "VSS_" + envName(name) + "_" + strconv.Itoa(i) + "_" + secret
+		}
+		used[variable] = true
+		return "${" + variable + "}"
+	}
+
+	for _, cloud := range sorted {
+		scanEnabled := cloud.ScanEnabled
+		account := &CloudAccount{
+			Name:         cloud.Name,
+			Provider:     cloud.Provider,
+			Environment:  cloud.Environment,
+			Email:        cloud.Email,
+			UserName:     cloud.UserName,
+			IsDraft:      cloud.IsDraft,
+			ScanEnabled:  &scanEnabled,
+			ScanInterval: cloud.ScanInterval,
+			ScanRegion:   cloud.ScanRegion,
+		}
+		if len(cloud.Tags) > 0 {
+			account.Tags = append([]string(nil), cloud.Tags...)
+		}
+
+		if cloud.Provider == "Azure" {
+			account.SubscriptionID = cloud.SubscriptionID
+			account.ApplicationID = cloud.ApplicationID
+			account.DirectoryID = cloud.DirectoryID
+			account.KeyValue = placeholder(cloud.Name, "KEY_VALUE")
+		} else {
+			account.AccountID = cloud.AccountID
+			account.RoleArn = cloud.Arn
+			account.ExternalID = placeholder(cloud.Name, "EXTERNAL_ID")
+		}
+		m.CloudAccounts = append(m.CloudAccounts, account)
+	}
+	return m
+}
+
+//Placeholders returns the environment variables the secrets of the manifest
+//reference, in order
+func (m *Manifest) Placeholders() []string {
+	var names []string
+	collect := func(name string) string {
+		names = append(names, name)
+		return ""
+	}
+	for _, account := range m.CloudAccounts {
+		os.Expand(account.KeyValue, collect)
+		os.Expand(account.ExternalID, collect)
+	}
+	return names
+}
+
+// envName turns a cloud account name into the upper case letters, digits
+// and underscores of an environment variable name
+func envName(name string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+	if mapped = strings.Trim(mapped, "_"); mapped == "" {
+		return "CLOUD"
+	}
+	return mapped
+}
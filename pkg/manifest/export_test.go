@@ -0,0 +1,55 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportedClouds() []*client.CloudAccount {
+	return []*client.CloudAccount{
+		{ID: "id-2", RoleID: "role-id", RoleName: "securestate_role", AccountID: "123456789012", CloudInfo: client.CloudInfo{
+			Name: "prod aws", Provider: "AWS", Arn: "arn:aws:iam::123456789012:role/vss", ExternalID: "secret-ext",
+			Environment: "Production", Tags: []string{"pci"}, ScanEnabled: true, ScanInterval: "Weekly", ScanRegion: "All",
+			IsValid: true, LastValidationCheck: "2026-10-01T00:00:00Z"}},
+		{ID: "id-1", AccountID: "sub", CloudInfo: client.CloudInfo{
+			Name: "azure", Provider: "Azure", SubscriptionID: "sub", ApplicationID: "app", DirectoryID: "dir",
+			KeyValue: "secret-key", IsDraft: true, ScanInterval: "Daily", ScanRegion: "All"}},
+		{ID: "id-3", CloudInfo: client.CloudInfo{Name: "prod-aws", Provider: "AWS"}},
+	}
+}
+
+func TestExport(t *testing.T) {
+	enabled, disabled := true, false
+	m := Export(exportedClouds())
+
+	assert.Equal(t, &Manifest{CloudAccounts: []*CloudAccount{
+		{Name: "azure", Provider: "Azure", IsDraft: true, ScanEnabled: &disabled, ScanInterval: "Daily", ScanRegion: "All",
+			SubscriptionID: "sub", ApplicationID: "app", DirectoryID: "dir", KeyValue: "${VSS_AZURE_KEY_VALUE}"},
+		{Name: "prod aws", Provider: "AWS", Environment: "Production", Tags: []string{"pci"}, ScanEnabled: &enabled,
+			ScanInterval: "Weekly", ScanRegion: "All", AccountID: "123456789012", RoleArn: "arn:aws:iam::123456789012:role/vss",
+			ExternalID: "${VSS_PROD_AWS_EXTERNAL_ID}"},
+		{Name: "prod-aws", Provider: "AWS", ScanEnabled: &disabled, ExternalID: "${VSS_PROD_AWS_2_EXTERNAL_ID}"},
+	}}, m)
+	assert.Equal(t, []string{"VSS_AZURE_KEY_VALUE", "VSS_PROD_AWS_EXTERNAL_ID", "VSS_PROD_AWS_2_EXTERNAL_ID"}, m.Placeholders())
+}
+
+func TestExportPlansNoChanges(t *testing.T) {
+	clouds := exportedClouds()
+	assert.Empty(t, NewPlan(Export(clouds), clouds).Changes)
+}
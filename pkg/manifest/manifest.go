@@ -0,0 +1,296 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+//Package manifest reads and writes the cloud accounts of a team as YAML or CSV
+package manifest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Formats of manifest files
+const (
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+//CloudAccount is a cloud account as described in a manifest. AWS accounts
+//are added with an existing role (RoleArn and ExternalID) or a new role
+//created through AwsProfile (RoleName), Azure accounts with an application.
+type CloudAccount struct {
+	Name        string   `yaml:"name"`
+	Provider    string   `yaml:"provider"`
+	Environment string   `yaml:"environment,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Email       string   `yaml:"email,omitempty"`
+	UserName    string   `yaml:"userName,omitempty"`
+	IsDraft     bool     `yaml:"isDraft,omitempty"`
+
+	// Scan settings are left as they are when not given
+	ScanEnabled  *bool  `yaml:"scanEnabled,omitempty"`
+	ScanInterval string `yaml:"scanInterval,omitempty"`
+	ScanRegion   string `yaml:"scanRegion,omitempty"`
+
+	AccountID      string `yaml:"accountId,omitempty"`
+	AwsProfile     string `yaml:"awsProfile,omitempty"`
+	AwsProfilePath string `yaml:"awsProfilePath,omitempty"`
+	RoleName       string `yaml:"roleName,omitempty"`
+	RoleArn        string `yaml:"roleArn,omitempty"`
+	ExternalID     string `yaml:"externalId,omitempty"`
+	Policy         string `yaml:"policy,omitempty"`
+
+	SubscriptionID string `yaml:"subscriptionId,omitempty"`
+	ApplicationID  string `yaml:"applicationId,omitempty"`
+	DirectoryID    string `yaml:"directoryId,omitempty"`
+	KeyValue       string `yaml:"keyValue,omitempty"`
+	AuthFile       string `yaml:"authFile,omitempty"`
+	Region         string `yaml:"region,omitempty"`
+}
+
+//Manifest lists cloud accounts
+type Manifest struct {
+	CloudAccounts []*CloudAccount `yaml:"cloudAccounts"`
+}
+
+// column is a CSV column, named like the YAML key of its field
+type column struct {
+	name string
+	get  func(a *CloudAccount) string
+	set  func(a *CloudAccount, value string) error
+}
+
+func stringColumn(name string, field func(a *CloudAccount) *string) column {
+	return column{
+		name: name,
+		get:  func(a *CloudAccount) string { return *field(a) },
+		set: func(a *CloudAccount, value string) error {
+			*field(a) = value
+			return nil
+		},
+	}
+}
+
+// columns are in the order CSV files are written
+var columns = []column{
+	stringColumn("name", func(a *CloudAccount) *string { return &a.Name }),
+	stringColumn("provider", func(a *CloudAccount) *string { return &a.Provider }),
+	stringColumn("environment", func(a *CloudAccount) *string { return &a.Environment }),
+	{
+		// Tags are separated by | like in the --tags flag
+		name: "tags",
+		get:  func(a *CloudAccount) string { return strings.Join(a.Tags, "|") },
+		set: func(a *CloudAccount, value string) error {
+			a.Tags = nil
+			if value != "" {
+				a.Tags = strings.Split(value, "|")
+			}
+			return nil
+		},
+	},
+	stringColumn("email", func(a *CloudAccount) *string { return &a.Email }),
+	stringColumn("userName", func(a *CloudAccount) *string { return &a.UserName }),
+	{
+		name: "isDraft",
+		get: func(a *CloudAccount) string {
+			if !a.IsDraft {
+				return ""
+			}
+			return "true"
+		},
+		set: func(a *CloudAccount, value string) (err error) {
+			a.IsDraft = false
+			if value != "" {
+				a.IsDraft, err = strconv.ParseBool(value)
+			}
+			return err
+		},
+	},
+	{
+		name: "scanEnabled",
+		get: func(a *CloudAccount) string {
+			if a.ScanEnabled == nil {
+				return ""
+			}
+			return strconv.FormatBool(*a.ScanEnabled)
+		},
+		set: func(a *CloudAccount, value string) error {
+			a.ScanEnabled = nil
+			if value == "" {
+				return nil
+			}
+			enabled, err := strconv.ParseBool(value)
+			a.ScanEnabled = &enabled
+			return err
+		},
+	},
+	stringColumn("scanInterval", func(a *CloudAccount) *string { return &a.ScanInterval }),
+	stringColumn("scanRegion", func(a *CloudAccount) *string { return &a.ScanRegion }),
+	stringColumn("accountId", func(a *CloudAccount) *string { return &a.AccountID }),
+	stringColumn("awsProfile", func(a *CloudAccount) *string { return &a.AwsProfile }),
+	stringColumn("awsProfilePath", func(a *CloudAccount) *string { return &a.AwsProfilePath }),
+	stringColumn("roleName", func(a *CloudAccount) *string { return &a.RoleName }),
+	stringColumn("roleArn", func(a *CloudAccount) *string { return &a.RoleArn }),
+	stringColumn("externalId", func(a *CloudAccount) *string { return &a.ExternalID }),
+	stringColumn("policy", func(a *CloudAccount) *string { return &a.Policy }),
+	stringColumn("subscriptionId", func(a *CloudAccount) *string { return &a.SubscriptionID }),
+	stringColumn("applicationId", func(a *CloudAccount) *string { return &a.ApplicationID }),
+	stringColumn("directoryId", func(a *CloudAccount) *string { return &a.DirectoryID }),
+	stringColumn("keyValue", func(a *CloudAccount) *string { return &a.KeyValue }),
+	stringColumn("authFile", func(a *CloudAccount) *string { return &a.AuthFile }),
+	stringColumn("region", func(a *CloudAccount) *string { return &a.Region }),
+}
+
+//FormatOf returns the format of a manifest file from its extension
+func FormatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported manifest %s, use a .yaml, .yml or .csv file", path)
+	}
+}
+
+//Load reads a YAML or CSV manifest, telling them apart by extension
+func Load(path string) (*Manifest, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %s", path, err)
+	}
+	return m, nil
+}
+
+//Parse reads a manifest in format
+func Parse(data []byte, format string) (*Manifest, error) {
+	if format == FormatCSV {
+		return parseCSV(data)
+	}
+
+	m := &Manifest{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, err
+	}
+	for i, account := range m.CloudAccounts {
+		if account == nil {
+			return nil, fmt.Errorf("cloud account %d is empty", i+1)
+		}
+	}
+	return m, nil
+}
+
+func parseCSV(data []byte) (*Manifest, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{CloudAccounts: make([]*CloudAccount, 0)}
+	if len(records) == 0 {
+		return m, nil
+	}
+
+	byName := map[string]column{}
+	for _, c := range columns {
+		byName[strings.ToLower(c.name)] = c
+	}
+	header := make([]column, len(records[0]))
+	for i, name := range records[0] {
+		c, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		header[i] = c
+	}
+
+	for line, record := range records[1:] {
+		account := &CloudAccount{}
+		for i, value := range record {
+			if err := header[i].set(account, strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("line %d, column %s: %s", line+2, header[i].name, err)
+			}
+		}
+		m.CloudAccounts = append(m.CloudAccounts, account)
+	}
+	return m, nil
+}
+
+//Save writes the manifest to path in the format of its extension, readable
+//only by the current user since it may hold secrets
+func (m *Manifest) Save(path string) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err := m.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+//Write writes the manifest in format
+func (m *Manifest) Write(w io.Writer, format string) error {
+	if format != FormatCSV {
+		data, err := yaml.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	cw.Write(record)
+	for _, account := range m.CloudAccounts {
+		for i, c := range columns {
+			record[i] = c.get(account)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
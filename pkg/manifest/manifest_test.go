@@ -0,0 +1,111 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package manifest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testManifest() *Manifest {
+	return &Manifest{CloudAccounts: []*CloudAccount{
+		{Name: "prod", Provider: "AWS", Environment: "Production", AccountID: "123456789012", AwsProfile: "prod", RoleName: "securestate_role", Tags: []string{"team:web", "pci"}},
+		{Name: "azure-prod", Provider: "Azure", SubscriptionID: "sub", ApplicationID: "app", DirectoryID: "dir", KeyValue: "${AZURE_KEY}", IsDraft: true},
+	}}
+}
+
+func TestParseYAML(t *testing.T) {
+	m, err := Parse([]byte(`cloudAccounts:
+- name: prod
+  provider: AWS
+  environment: Production
+  tags: [team:web, pci]
+  accountId: "123456789012"
+  awsProfile: prod
+  roleName: securestate_role
+- name: azure-prod
+  provider: Azure
+  isDraft: true
+  subscriptionId: sub
+  applicationId: app
+  directoryId: dir
+  keyValue: ${AZURE_KEY}
+`), FormatYAML)
+	assert.Nil(t, err)
+	assert.Equal(t, testManifest(), m)
+
+	_, err = Parse([]byte("cloudAccounts:\n- name: prod\n  role: r\n"), FormatYAML)
+	assert.NotNil(t, err, "unknown keys are rejected")
+	_, err = Parse([]byte("cloudAccounts:\n-\n"), FormatYAML)
+	assert.EqualError(t, err, "cloud account 1 is empty")
+}
+
+func TestParseCSV(t *testing.T) {
+	m, err := Parse([]byte(`# exported accounts
+name, provider, environment, tags, accountId, awsProfile, roleName, isDraft, subscriptionId, applicationId, directoryId, keyValue
+prod,AWS,Production,team:web|pci,123456789012,prod,securestate_role,,,,,
+azure-prod,Azure,,,,,,true,sub,app,dir,${AZURE_KEY}
+`), FormatCSV)
+	assert.Nil(t, err)
+	assert.Equal(t, testManifest(), m)
+
+	_, err = Parse([]byte("name,role\nprod,r\n"), FormatCSV)
+	assert.EqualError(t, err, `unknown column "role"`)
+	_, err = Parse([]byte("name,isDraft\nprod,maybe\n"), FormatCSV)
+	assert.Contains(t, err.Error(), "line 2, column isDraft")
+	_, err = Parse([]byte("name,provider\nprod\n"), FormatCSV)
+	assert.NotNil(t, err, "rows need every column")
+
+	m, err = Parse(nil, FormatCSV)
+	assert.Nil(t, err)
+	assert.Empty(t, m.CloudAccounts)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "vss-manifest")
+	defer os.RemoveAll(dir)
+
+	saved := testManifest()
+	disabled := false
+	saved.CloudAccounts[1].ScanEnabled = &disabled
+	saved.CloudAccounts[1].ScanInterval = "Daily"
+	for _, name := range []string{"accounts.yaml", "accounts.yml", "accounts.csv"} {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, saved.Save(path), name)
+		info, _ := os.Stat(path)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), name)
+
+		m, err := Load(path)
+		assert.Nil(t, err, name)
+		assert.Equal(t, saved, m, name)
+	}
+
+	_, err := Load(filepath.Join(dir, "accounts.json"))
+	assert.Contains(t, err.Error(), "use a .yaml, .yml or .csv file")
+	assert.NotNil(t, testManifest().Save(filepath.Join(dir, "accounts.txt")))
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, (&Manifest{CloudAccounts: testManifest().CloudAccounts[:1]}).Write(&buf, FormatCSV))
+	assert.Equal(t, "name,provider,environment,tags,email,userName,isDraft,scanEnabled,scanInterval,scanRegion,accountId,awsProfile,awsProfilePath,roleName,roleArn,externalId,policy,subscriptionId,applicationId,directoryId,keyValue,authFile,region\n"+
+		"prod,AWS,Production,team:web|pci,,,,,,,123456789012,prod,,securestate_role,,,,,,,,,\n", buf.String())
+}
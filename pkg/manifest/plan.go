@@ -0,0 +1,153 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+// Actions of a plan
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+//FieldChange is a field of a cloud account that an update changes
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+//Change brings one cloud account of the team in line with the manifest
+type Change struct {
+	Action   string         `json:"action"`
+	Name     string         `json:"name"`
+	Provider string         `json:"provider"`
+	CloudID  string         `json:"cloudId,omitempty"`
+	Fields   []*FieldChange `json:"fields,omitempty"`
+
+	// Account is the manifest entry of creates and updates
+	Account *CloudAccount `json:"-"`
+	// Current is the cloud account of updates and deletes
+	Current *client.CloudAccount `json:"-"`
+}
+
+//Plan lists the changes to make the cloud accounts of a team match a
+//manifest, creates first and deletes last
+type Plan struct {
+	Changes []*Change `json:"changes"`
+}
+
+//NewPlan compares the manifest with the cloud accounts of the team by name.
+//Accounts missing from the team are created, those only in the team are
+//deleted. Tags, role ARN and scan settings left out of the manifest are left
+//as they are, while environment and draft flag are always compared.
+func NewPlan(m *Manifest, current []*client.CloudAccount) *Plan {
+	byName := map[string]*client.CloudAccount{}
+	for _, cloud := range current {
+		byName[cloud.Name] = cloud
+	}
+
+	var creates, updates, deletes []*Change
+	listed := map[string]bool{}
+	for _, account := range m.CloudAccounts {
+		listed[account.Name] = true
+		cloud, ok := byName[account.Name]
+		if !ok {
+			creates = append(creates, &Change{Action: ActionCreate, Name: account.Name, Provider: account.Provider, Account: account})
+			continue
+		}
+		if fields := diff(account, cloud); len(fields) > 0 {
+			updates = append(updates, &Change{Action: ActionUpdate, Name: account.Name, Provider: cloud.Provider,
+				CloudID: cloud.ID, Fields: fields, Account: account, Current: cloud})
+		}
+	}
+
+	for _, cloud := range current {
+		if !listed[cloud.Name] {
+			deletes = append(deletes, &Change{Action: ActionDelete, Name: cloud.Name, Provider: cloud.Provider, CloudID: cloud.ID, Current: cloud})
+		}
+	}
+	sort.SliceStable(deletes, func(i, j int) bool { return deletes[i].Name < deletes[j].Name })
+
+	p := &Plan{Changes: make([]*Change, 0, len(creates)+len(updates)+len(deletes))}
+	p.Changes = append(p.Changes, creates...)
+	p.Changes = append(p.Changes, updates...)
+	p.Changes = append(p.Changes, deletes...)
+	return p
+}
+
+//Count returns the number of changes with action
+func (p *Plan) Count(action string) int {
+	n := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// diff returns the fields of cloud that differ from account
+func diff(account *CloudAccount, cloud *client.CloudAccount) []*FieldChange {
+	var fields []*FieldChange
+	compare := func(field, from, to string) {
+		if from != to {
+			fields = append(fields, &FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	compare("environment", cloud.Environment, account.Environment)
+	compare("isDraft", strconv.FormatBool(cloud.IsDraft), strconv.FormatBool(account.IsDraft))
+	if len(account.Tags) > 0 {
+		compare("tags", joinTags(cloud.Tags), joinTags(account.Tags))
+	}
+	if account.RoleArn != "" {
+		compare("roleArn", cloud.Arn, account.RoleArn)
+	}
+	if account.ScanEnabled != nil {
+		compare("scanEnabled", strconv.FormatBool(cloud.ScanEnabled), strconv.FormatBool(*account.ScanEnabled))
+	}
+	if account.ScanInterval != "" {
+		compare("scanInterval", cloud.ScanInterval, account.ScanInterval)
+	}
+	if account.ScanRegion != "" {
+		compare("scanRegion", cloud.ScanRegion, account.ScanRegion)
+	}
+	return fields
+}
+
+// joinTags joins tags in order so that reordering them is not a change
+func joinTags(tags []string) string {
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "|")
+}
+
+//String describes the change on one line, like "~ update prod (ID)"
+func (c *Change) String() string {
+	symbol := map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+	if c.CloudID == "" {
+		return fmt.Sprintf("%s %s %s (%s)", symbol, c.Action, c.Name, c.Provider)
+	}
+	return fmt.Sprintf("%s %s %s (%s %s)", symbol, c.Action, c.Name, c.Provider, c.CloudID)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlan(t *testing.T) {
+	enabled := true
+	m := &Manifest{CloudAccounts: []*CloudAccount{
+		{Name: "same", Environment: "Production", Tags: []string{"b", "a"}, ScanEnabled: &enabled},
+		{Name: "draft", IsDraft: true, RoleArn: "arn:new"},
+		{Name: "new", Provider: "Azure"},
+	}}
+	current := []*client.CloudAccount{
+		{ID: "id-z", CloudInfo: client.CloudInfo{Name: "zombie", Provider: "AWS"}},
+		{ID: "id-same", CloudInfo: client.CloudInfo{Name: "same", Provider: "AWS", Environment: "Production",
+			Tags: []string{"a", "b"}, ScanEnabled: true, ScanInterval: "Weekly"}},
+		{ID: "id-draft", CloudInfo: client.CloudInfo{Name: "draft", Provider: "AWS", Arn: "arn:old", Tags: []string{"kept"}}},
+		{ID: "id-a", CloudInfo: client.CloudInfo{Name: "abandoned", Provider: "Azure"}},
+	}
+
+	p := NewPlan(m, current)
+	lines := make([]string, len(p.Changes))
+	for i, change := range p.Changes {
+		lines[i] = change.String()
+	}
+	assert.Equal(t, []string{
+		"+ create new (Azure)",
+		"~ update draft (AWS id-draft)",
+		"- delete abandoned (Azure id-a)",
+		"- delete zombie (AWS id-z)",
+	}, lines)
+	assert.Equal(t, []*FieldChange{
+		{Field: "isDraft", From: "false", To: "true"},
+		{Field: "roleArn", From: "arn:old", To: "arn:new"},
+	}, p.Changes[1].Fields, "tags left out of the manifest are kept")
+	assert.Equal(t, 2, p.Count(ActionDelete))
+
+	assert.Empty(t, NewPlan(&Manifest{}, nil).Changes)
+}
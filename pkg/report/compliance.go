@@ -0,0 +1,239 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//Package report renders findings as reports for auditors
+package report
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+// generalControl holds the rules mapped to a framework without a control
+const generalControl = "General"
+
+//Control is a control of a compliance framework, it fails when any rule
+//mapped to it has an open finding
+type Control struct {
+	ID      string
+	Rules   []*client.Rule
+	Failing []*client.Finding
+}
+
+//Passed reports whether no rule of the control has an open finding
+func (c *Control) Passed() bool {
+	return len(c.Failing) == 0
+}
+
+//Compliance is the compliance of cloud accounts with a framework
+type Compliance struct {
+	Framework       string
+	GeneratedAt     time.Time
+	Profile         string
+	Endpoint        string
+	CloudAccountIDs []string
+	Controls        []*Control
+	// RuleNames maps rule IDs to their names
+	RuleNames map[string]string
+}
+
+//Passed counts the passing controls
+func (r *Compliance) Passed() int {
+	passed := 0
+	for _, control := range r.Controls {
+		if control.Passed() {
+			passed++
+		}
+	}
+	return passed
+}
+
+//Failed counts the failing controls
+func (r *Compliance) Failed() int {
+	return len(r.Controls) - r.Passed()
+}
+
+//FrameworkRules returns the rules mapped to framework, matched ignoring case
+func FrameworkRules(rules []*client.Rule, framework string) []*client.Rule {
+	matching := make([]*client.Rule, 0)
+	for _, rule := range rules {
+		for _, mapping := range rule.Compliance {
+			if strings.EqualFold(mapping.Framework, framework) {
+				matching = append(matching, rule)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+//Frameworks lists the frameworks rules map to, sorted
+func Frameworks(rules []*client.Rule) []string {
+	seen := map[string]bool{}
+	names := make([]string, 0)
+	for _, rule := range rules {
+		for _, mapping := range rule.Compliance {
+			if !seen[mapping.Framework] {
+				seen[mapping.Framework] = true
+				names = append(names, mapping.Framework)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+//NewCompliance maps the open findings of the rules of framework to its controls
+func NewCompliance(framework string, rules []*client.Rule, findings []*client.Finding) *Compliance {
+	r := &Compliance{Framework: framework, RuleNames: map[string]string{}}
+	controls := map[string]*Control{}
+	rulesControls := map[string][]*Control{}
+	named := false
+
+	for _, rule := range FrameworkRules(rules, framework) {
+		r.RuleNames[rule.ID] = rule.Name
+		for _, mapping := range rule.Compliance {
+			if !strings.EqualFold(mapping.Framework, framework) {
+				continue
+			}
+			if !named {
+				// Show the framework as the catalog spells it
+				r.Framework, named = mapping.Framework, true
+			}
+			ids := mapping.Controls
+			if len(ids) == 0 {
+				ids = []string{generalControl}
+			}
+			for _, id := range ids {
+				if controls[id] == nil {
+					controls[id] = &Control{ID: id}
+					r.Controls = append(r.Controls, controls[id])
+				}
+				controls[id].Rules = append(controls[id].Rules, rule)
+				rulesControls[rule.ID] = append(rulesControls[rule.ID], controls[id])
+			}
+		}
+	}
+
+	for _, finding := range findings {
+		if !strings.EqualFold(finding.Status, "Open") {
+			continue
+		}
+		for _, control := range rulesControls[finding.RuleID] {
+			control.Failing = append(control.Failing, finding)
+		}
+	}
+
+	sort.Slice(r.Controls, func(i, j int) bool { return controlLess(r.Controls[i].ID, r.Controls[j].ID) })
+	for _, control := range r.Controls {
+		sort.Slice(control.Failing, func(i, j int) bool {
+			a, b := control.Failing[i], control.Failing[j]
+			for _, pair := range [][2]string{
+				{a.RuleID, b.RuleID},
+				{a.CloudAccountID, b.CloudAccountID},
+				{a.Region, b.Region},
+			} {
+				if pair[0] != pair[1] {
+					return pair[0] < pair[1]
+				}
+			}
+			return a.ObjectID < b.ObjectID
+		})
+	}
+	return r
+}
+
+// controlLess orders control IDs such as 1.2, 1.10 and 2.1 numerically part by
+// part, falling back to text for parts that are not numbers
+func controlLess(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return an < bn
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
+
+//WriteHTML renders the report as a single HTML file that needs no network
+func (r *Compliance) WriteHTML(w io.Writer) error {
+	return complianceTemplate.Execute(w, r)
+}
+
+var complianceTemplate = template.Must(template.New("compliance").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"time": func(t time.Time) string { return t.UTC().Format(time.RFC3339) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Framework}} compliance report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { margin-bottom: 0.2em; }
+table { border-collapse: collapse; margin: 1em 0; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; }
+.meta td:first-child { font-weight: bold; width: 12em; }
+.pass { color: #1a7f37; font-weight: bold; }
+.fail { color: #cf222e; font-weight: bold; }
+.control { margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Framework}} compliance report</h1>
+<table class="meta">
+<tr><td>Generated</td><td>{{time .GeneratedAt}}</td></tr>
+<tr><td>Profile</td><td>{{.Profile}}</td></tr>
+<tr><td>Endpoint</td><td>{{.Endpoint}}</td></tr>
+<tr><td>Cloud accounts</td><td>{{if .CloudAccountIDs}}{{join .CloudAccountIDs ", "}}{{else}}All cloud accounts{{end}}</td></tr>
+<tr><td>Controls</td><td><span class="pass">{{.Passed}} passed</span>, <span class="fail">{{.Failed}} failed</span></td></tr>
+</table>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Control</th><th>Result</th><th>Rules</th><th>Failing resources</th></tr>
+{{- range .Controls}}
+<tr><td>{{if .Passed}}{{.ID}}{{else}}<a href="#control-{{.ID}}">{{.ID}}</a>{{end}}</td><td>{{if .Passed}}<span class="pass">Pass</span>{{else}}<span class="fail">Fail</span>{{end}}</td><td>{{len .Rules}}</td><td>{{len .Failing}}</td></tr>
+{{- end}}
+</table>
+{{- $names := .RuleNames}}
+{{- range .Controls}}
+{{- if not .Passed}}
+<div class="control" id="control-{{.ID}}">
+<h3>Control {{.ID}}: <span class="fail">Fail</span></h3>
+<table>
+<tr><th>Rule</th><th>Severity</th><th>Cloud account</th><th>Region</th><th>Resource</th></tr>
+{{- range .Failing}}
+<tr><td>{{index $names .RuleID}} ({{.RuleID}})</td><td>{{.Level}}</td><td>{{.CloudAccountID}}</td><td>{{.Region}}</td><td>{{.ObjectID}}</td></tr>
+{{- end}}
+</table>
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,81 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRules() []*client.Rule {
+	return []*client.Rule{
+		{ID: "s3-public", Name: "Bucket is public", Compliance: []*client.ComplianceMapping{{Framework: "CIS AWS", Controls: []string{"2.10", "1.2"}}}},
+		{ID: "iam-mfa", Name: "MFA is off", Compliance: []*client.ComplianceMapping{{Framework: "CIS AWS", Controls: []string{"2.9"}}, {Framework: "PCI"}}},
+		{ID: "vm-disk", Name: "Disk is not encrypted", Compliance: []*client.ComplianceMapping{{Framework: "PCI", Controls: []string{"3.4"}}}},
+	}
+}
+
+func TestNewCompliance(t *testing.T) {
+	findings := []*client.Finding{
+		{RuleID: "s3-public", Status: "Open", CloudAccountID: "c2", ObjectID: "<bucket>"},
+		{RuleID: "s3-public", Status: "Open", CloudAccountID: "c1", ObjectID: "bucket-1"},
+		{RuleID: "iam-mfa", Status: "Suppressed", ObjectID: "user-1"},
+		{RuleID: "vm-disk", Status: "Open", ObjectID: "disk-1"},
+	}
+
+	r := NewCompliance("cis aws", testRules(), findings)
+	assert.Equal(t, "CIS AWS", r.Framework)
+	assert.Equal(t, 3, len(r.Controls))
+	assert.Equal(t, "1.2", r.Controls[0].ID)
+	assert.Equal(t, "2.9", r.Controls[1].ID)
+	assert.Equal(t, "2.10", r.Controls[2].ID)
+	assert.True(t, r.Controls[1].Passed())
+	assert.Equal(t, []*client.Finding{findings[1], findings[0]}, r.Controls[2].Failing)
+	assert.Equal(t, 1, r.Passed())
+	assert.Equal(t, 2, r.Failed())
+
+	pci := NewCompliance("PCI", testRules(), findings)
+	assert.Equal(t, "3.4", pci.Controls[0].ID)
+	assert.Equal(t, generalControl, pci.Controls[1].ID)
+}
+
+func TestFrameworks(t *testing.T) {
+	assert.Equal(t, []string{"CIS AWS", "PCI"}, Frameworks(testRules()))
+	assert.Equal(t, 0, len(FrameworkRules(testRules(), "HIPAA")))
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := NewCompliance("CIS AWS", testRules(), []*client.Finding{{RuleID: "s3-public", Status: "Open", CloudAccountID: "c1", ObjectID: "<bucket>"}})
+	r.GeneratedAt = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	r.Profile = "audit"
+	r.Endpoint = "https://app.securestate.vmware.com/api"
+
+	var buf bytes.Buffer
+	assert.Nil(t, r.WriteHTML(&buf))
+	html := buf.String()
+	assert.Contains(t, html, "<title>CIS AWS compliance report</title>")
+	assert.Contains(t, html, "2026-10-18T12:00:00Z")
+	assert.Contains(t, html, "<td>audit</td>")
+	assert.Contains(t, html, "All cloud accounts")
+	assert.Contains(t, html, "1 passed")
+	assert.Contains(t, html, "Bucket is public (s3-public)")
+	assert.Contains(t, html, "&lt;bucket&gt;")
+	assert.NotContains(t, html, "<bucket>")
+	assert.NotContains(t, html, "http://", "the report must not load anything")
+}
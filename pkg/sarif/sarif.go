@@ -0,0 +1,289 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif converts Secure State findings to a SARIF 2.1.0 log
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/CloudCoreo/cli/client"
+)
+
+const (
+	//Version of the SARIF format written
+	Version = "2.1.0"
+
+	//Schema of the SARIF format written
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// toolName is the driver name results are reported under
+	toolName = "VMware Secure State"
+
+	// informationURI documents the tool
+	informationURI = "https://api.securestate.vmware.com"
+
+	// uriBaseID is the root cloud resource locations are relative to
+	uriBaseID = "SECURESTATE"
+
+	// fingerprintKey identifies a finding across runs
+	fingerprintKey = "secureStateFindingId/v1"
+)
+
+// Log is a SARIF log
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+// Run is the output of a single tool run
+type Run struct {
+	Tool               Tool                         `json:"tool"`
+	OriginalURIBaseIDs map[string]*ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []*Result                    `json:"results"`
+}
+
+// Tool describes the tool that produced the results
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the component of the tool holding the rules
+type Driver struct {
+	Name           string  `json:"name"`
+	Version        string  `json:"version,omitempty"`
+	InformationURI string  `json:"informationUri"`
+	Rules          []*Rule `json:"rules"`
+}
+
+// Rule is a reporting descriptor of a Secure State rule
+type Rule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *Message               `json:"shortDescription,omitempty"`
+	DefaultConfiguration *Configuration         `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Configuration is the default configuration of a rule
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// Message is a plain text message
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a rule violated by a cloud object
+type Result struct {
+	RuleID              string                 `json:"ruleId"`
+	RuleIndex           int                    `json:"ruleIndex"`
+	Kind                string                 `json:"kind,omitempty"`
+	Level               string                 `json:"level"`
+	Message             Message                `json:"message"`
+	Locations           []*Location            `json:"locations"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Suppressions        []*Suppression         `json:"suppressions,omitempty"`
+	Properties          map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Location is where a result was found
+type Location struct {
+	PhysicalLocation *PhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []*LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// PhysicalLocation points at an artifact
+type PhysicalLocation struct {
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation"`
+}
+
+// ArtifactLocation is the URI of an artifact
+type ArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// LogicalLocation names a cloud resource
+type LogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Suppression marks a result as suppressed outside of the log
+type Suppression struct {
+	Kind string `json:"kind"`
+}
+
+// Level maps a Secure State severity to a SARIF level
+func Level(severity string) string {
+	switch strings.ToLower(severity) {
+	case "high":
+		return "error"
+	case "low":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// FromFindings builds a log of findings. Rules and results are sorted so the
+// same findings always give the same log
+func FromFindings(findings []*client.Finding, toolVersion string) *Log {
+	sorted := make([]*client.Finding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		for _, pair := range [][2]string{
+			{a.RuleID, b.RuleID},
+			{a.CloudAccountID, b.CloudAccountID},
+			{a.Region, b.Region},
+			{a.ObjectID, b.ObjectID},
+			{a.ID, b.ID},
+		} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return false
+	})
+
+	run := &Run{
+		Tool: Tool{Driver: Driver{
+			Name:           toolName,
+			Version:        toolVersion,
+			InformationURI: informationURI,
+			Rules:          []*Rule{},
+		}},
+		OriginalURIBaseIDs: map[string]*ArtifactLocation{uriBaseID: {URI: "cloud:///"}},
+		Results:            []*Result{},
+	}
+
+	ruleIndex := map[string]int{}
+	for _, finding := range sorted {
+		index, ok := ruleIndex[finding.RuleID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[finding.RuleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newRule(finding))
+		}
+		run.Results = append(run.Results, newResult(finding, index))
+	}
+
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs:    []*Run{run},
+	}
+}
+
+func newRule(finding *client.Finding) *Rule {
+	description := finding.RuleName
+	if description == "" {
+		description = finding.RuleID
+	}
+
+	rule := &Rule{
+		ID:                   finding.RuleID,
+		Name:                 finding.RuleName,
+		ShortDescription:     &Message{Text: description},
+		DefaultConfiguration: &Configuration{Level: Level(finding.Level)},
+	}
+	if finding.Provider != "" {
+		rule.Properties = map[string]interface{}{"provider": finding.Provider}
+	}
+	return rule
+}
+
+func newResult(finding *client.Finding, ruleIndex int) *Result {
+	name := finding.RuleName
+	if name == "" {
+		name = finding.RuleID
+	}
+
+	result := &Result{
+		RuleID:    finding.RuleID,
+		RuleIndex: ruleIndex,
+		Level:     Level(finding.Level),
+		Message:   Message{Text: name + ": " + finding.ObjectID},
+		Locations: []*Location{resourceLocation(finding)},
+		Properties: map[string]interface{}{
+			"cloudAccountId": finding.CloudAccountID,
+			"provider":       finding.Provider,
+			"region":         finding.Region,
+			"severity":       finding.Level,
+			"status":         finding.Status,
+		},
+	}
+	if finding.ID != "" {
+		result.PartialFingerprints = map[string]string{fingerprintKey: finding.ID}
+	}
+	if finding.Service != "" {
+		result.Properties["service"] = finding.Service
+	}
+	if finding.FirstObservedTimestamp != "" {
+		result.Properties["firstObserved"] = finding.FirstObservedTimestamp
+	}
+
+	switch strings.ToLower(finding.Status) {
+	case "resolved":
+		result.Kind = "pass"
+		result.Level = "none"
+	case "suppressed":
+		result.Suppressions = []*Suppression{{Kind: "external"}}
+	}
+	return result
+}
+
+// resourceLocation locates the object of finding as
+// provider/cloud account/region/object
+func resourceLocation(finding *client.Finding) *Location {
+	segments := []string{finding.Provider, finding.CloudAccountID, finding.Region, finding.ObjectID}
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	name := finding.ObjectPath
+	if name == "" {
+		name = finding.ObjectID
+	}
+
+	return &Location{
+		PhysicalLocation: &PhysicalLocation{ArtifactLocation: &ArtifactLocation{
+			URI:       strings.Join(escaped, "/"),
+			URIBaseID: uriBaseID,
+		}},
+		LogicalLocations: []*LogicalLocation{{
+			Name:               name,
+			FullyQualifiedName: strings.Join(segments, "/"),
+			Kind:               "resource",
+		}},
+	}
+}
+
+// Write encodes log to w as indented JSON
+func Write(w io.Writer, log *Log) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFindings() []*client.Finding {
+	return []*client.Finding{
+		{ID: "f3", RuleID: "rule-b", RuleName: "Bucket is public", Level: "High", Status: "Open", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket 2"},
+		{ID: "f1", RuleID: "rule-a", Level: "Low", Status: "Suppressed", Provider: "Azure", CloudAccountID: "c2", Region: "westus", ObjectID: "vm-1"},
+		{ID: "f2", RuleID: "rule-b", RuleName: "Bucket is public", Level: "High", Status: "Resolved", Provider: "AWS", CloudAccountID: "c1", Region: "us-east-1", ObjectID: "bucket-1"},
+	}
+}
+
+func TestFromFindings(t *testing.T) {
+	log := FromFindings(testFindings(), "1.0.0")
+	assert.Equal(t, Version, log.Version)
+	run := log.Runs[0]
+	assert.Equal(t, "1.0.0", run.Tool.Driver.Version)
+
+	assert.Equal(t, 2, len(run.Tool.Driver.Rules))
+	assert.Equal(t, "rule-a", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "rule-a", run.Tool.Driver.Rules[0].ShortDescription.Text)
+	assert.Equal(t, "note", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+	assert.Equal(t, "Bucket is public", run.Tool.Driver.Rules[1].Name)
+	assert.Equal(t, "error", run.Tool.Driver.Rules[1].DefaultConfiguration.Level)
+
+	assert.Equal(t, 3, len(run.Results))
+	suppressed, open, resolved := run.Results[0], run.Results[1], run.Results[2]
+	assert.Equal(t, 0, suppressed.RuleIndex)
+	assert.Equal(t, "external", suppressed.Suppressions[0].Kind)
+	assert.Equal(t, "f2", resolved.PartialFingerprints[fingerprintKey])
+	assert.Equal(t, "pass", resolved.Kind)
+	assert.Equal(t, "none", resolved.Level)
+	assert.Equal(t, 1, open.RuleIndex)
+	assert.Equal(t, "error", open.Level)
+	assert.Equal(t, "Bucket is public: bucket 2", open.Message.Text)
+	assert.Equal(t, "AWS/c1/us-east-1/bucket%202", open.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "AWS/c1/us-east-1/bucket 2", open.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestFromFindingsDeterministic(t *testing.T) {
+	findings := testFindings()
+	reversed := []*client.Finding{findings[2], findings[1], findings[0]}
+
+	var a, b bytes.Buffer
+	assert.Nil(t, Write(&a, FromFindings(findings, "1.0.0")))
+	assert.Nil(t, Write(&b, FromFindings(reversed, "1.0.0")))
+	assert.Equal(t, a.String(), b.String())
+	assert.Equal(t, "f3", findings[0].ID, "input must not be reordered")
+}
+
+func TestFromFindingsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, Write(&buf, FromFindings(nil, "")))
+
+	decoded := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &decoded))
+	run := decoded["runs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, run["results"])
+	assert.Equal(t, []interface{}{}, run["tool"].(map[string]interface{})["driver"].(map[string]interface{})["rules"])
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, "error", Level("HIGH"))
+	assert.Equal(t, "warning", Level("Medium"))
+	assert.Equal(t, "note", Level("low"))
+	assert.Equal(t, "warning", Level(""))
+}
@@ -0,0 +1,168 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//Package snapshot saves findings to a file and compares two sets of findings
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/CloudCoreo/cli/pkg/baseline"
+)
+
+//Version of the snapshot file format written
+const Version = 1
+
+//Snapshot is the findings matching Filter at TakenAt
+type Snapshot struct {
+	Version  int                  `json:"version"`
+	TakenAt  time.Time            `json:"takenAt"`
+	Filter   client.FindingFilter `json:"filter"`
+	Findings []*client.Finding    `json:"findings"`
+}
+
+//Group is the change of the findings of a rule in a cloud account
+type Group struct {
+	CloudAccountID string            `json:"cloudAccountId"`
+	RuleID         string            `json:"ruleId"`
+	New            []*client.Finding `json:"new"`
+	Resolved       []*client.Finding `json:"resolved"`
+	StillOpen      []*client.Finding `json:"stillOpen"`
+}
+
+//Diff is the change of open findings between two points in time
+type Diff struct {
+	New       int      `json:"new"`
+	Resolved  int      `json:"resolved"`
+	StillOpen int      `json:"stillOpen"`
+	Groups    []*Group `json:"groups"`
+}
+
+//New returns a snapshot of findings
+func New(findings []*client.Finding, filter client.FindingFilter, takenAt time.Time) *Snapshot {
+	return &Snapshot{
+		Version:  Version,
+		TakenAt:  takenAt.UTC(),
+		Filter:   filter,
+		Findings: findings,
+	}
+}
+
+//Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %s", path, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s", s.Version, path)
+	}
+	return s, nil
+}
+
+//Save writes the snapshot to path as indented JSON
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// open indexes the open findings by fingerprint
+func open(findings []*client.Finding) map[string]*client.Finding {
+	open := map[string]*client.Finding{}
+	for _, finding := range findings {
+		if strings.EqualFold(finding.Status, "Open") {
+			open[baseline.Fingerprint(finding)] = finding
+		}
+	}
+	return open
+}
+
+//Compare returns how the open findings changed from before to after, grouped
+//by cloud account and rule. Findings are matched as in a baseline, so a
+//finding whose severity or timestamps changed is still open.
+func Compare(before, after []*client.Finding) *Diff {
+	openBefore, openAfter := open(before), open(after)
+	groups := map[[2]string]*Group{}
+	group := func(finding *client.Finding) *Group {
+		key := [2]string{finding.CloudAccountID, finding.RuleID}
+		if groups[key] == nil {
+			groups[key] = &Group{
+				CloudAccountID: finding.CloudAccountID,
+				RuleID:         finding.RuleID,
+				New:            []*client.Finding{},
+				Resolved:       []*client.Finding{},
+				StillOpen:      []*client.Finding{},
+			}
+		}
+		return groups[key]
+	}
+
+	diff := &Diff{Groups: []*Group{}}
+	for fingerprint, finding := range openAfter {
+		g := group(finding)
+		if openBefore[fingerprint] == nil {
+			g.New = append(g.New, finding)
+			diff.New++
+		} else {
+			g.StillOpen = append(g.StillOpen, finding)
+			diff.StillOpen++
+		}
+	}
+	for fingerprint, finding := range openBefore {
+		if openAfter[fingerprint] == nil {
+			g := group(finding)
+			g.Resolved = append(g.Resolved, finding)
+			diff.Resolved++
+		}
+	}
+
+	for _, g := range groups {
+		for _, findings := range [][]*client.Finding{g.New, g.Resolved, g.StillOpen} {
+			sortFindings(findings)
+		}
+		diff.Groups = append(diff.Groups, g)
+	}
+	sort.Slice(diff.Groups, func(i, j int) bool {
+		a, b := diff.Groups[i], diff.Groups[j]
+		if a.CloudAccountID != b.CloudAccountID {
+			return a.CloudAccountID < b.CloudAccountID
+		}
+		return a.RuleID < b.RuleID
+	})
+	return diff
+}
+
+func sortFindings(findings []*client.Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.ObjectID < b.ObjectID
+	})
+}
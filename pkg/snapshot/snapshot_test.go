@@ -0,0 +1,77 @@
+// Copyright © 2016 Paul Allen <paul@cloudcoreo.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudCoreo/cli/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func finding(account, rule, object, status string) *client.Finding {
+	return &client.Finding{CloudAccountID: account, RuleID: rule, ObjectID: object, Status: status}
+}
+
+func TestCompare(t *testing.T) {
+	before := []*client.Finding{
+		finding("c1", "rule-1", "o1", "Open"),
+		finding("c1", "rule-1", "o2", "Open"),
+		finding("c2", "rule-2", "o3", "Open"),
+		finding("c2", "rule-2", "o4", "Suppressed"),
+	}
+	after := []*client.Finding{
+		finding("c1", "rule-1", "o2", "Open"),
+		finding("c2", "rule-2", "o3", "Resolved"),
+		finding("c2", "rule-2", "o4", "Open"),
+		finding("c1", "rule-3", "o5", "Open"),
+	}
+
+	diff := Compare(before, after)
+	assert.Equal(t, 2, diff.New)
+	assert.Equal(t, 2, diff.Resolved)
+	assert.Equal(t, 1, diff.StillOpen)
+
+	assert.Equal(t, 3, len(diff.Groups))
+	assert.Equal(t, "rule-1", diff.Groups[0].RuleID)
+	assert.Equal(t, []*client.Finding{before[0]}, diff.Groups[0].Resolved)
+	assert.Equal(t, []*client.Finding{after[0]}, diff.Groups[0].StillOpen)
+	assert.Equal(t, "rule-3", diff.Groups[1].RuleID)
+	assert.Equal(t, []*client.Finding{after[3]}, diff.Groups[1].New)
+	assert.Equal(t, "c2", diff.Groups[2].CloudAccountID)
+	assert.Equal(t, []*client.Finding{after[2]}, diff.Groups[2].New)
+	assert.Equal(t, []*client.Finding{before[2]}, diff.Groups[2].Resolved)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "vss-snapshot")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "snapshot.json")
+
+	s := New([]*client.Finding{finding("c1", "rule-1", "o1", "Open")}, client.FindingFilter{Levels: []string{"High"}}, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, s.Save(path))
+
+	loaded, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, s, loaded)
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"version": 2}`), 0644))
+	_, err = Load(path)
+	assert.NotNil(t, err)
+}